@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/marco-sacchi/go2jsonc"
+	"github.com/marco-sacchi/go2jsonc/distiller"
 )
 
 const version = "0.3.3"
@@ -20,6 +21,26 @@ func main() {
 		"pipe-separated bits representing struct fields types for which do not\n"+
 			"render the type in JSONC comments; when omitted all types will be\nrendered for all fields")
 	output := flag.String("out", "", "output JSONC filepath; when omitted the code is written to stdout")
+	examples := flag.String("examples", "live",
+		"rendering of examples of fields without default value: live or comment;\n"+
+			"when omitted they are rendered as values")
+	noSecretHeuristics := flag.Bool("no-secret-heuristics", false,
+		"detect secret fields only by directive or jsonc tag, not by name")
+	deprecated := flag.String("deprecated", "comment",
+		"rendering of deprecated fields: comment, omit or render; when omitted\nthey are rendered commented out")
+	typeStyle := flag.String("types", "go",
+		"style of types in JSONC comments: go, qualified or json; when omitted Go\ntypes qualified by package name are rendered")
+	naming := flag.String("naming", "as-is",
+		"naming of the keys of fields without a name in the json tag: as-is,\nlower-camel, snake, kebab or screaming-snake; when omitted field names\nare used as declared")
+	indent := flag.Int("indent", 0, "number of spaces of each indentation level; when omitted tabs are used")
+	tabWidth := flag.Int("tab-width", 4, "width of tabs used to compute the width of comment lines")
+	lineWidth := flag.Int("line-width", 0,
+		"maximum width of comment lines, longer lines are wrapped; when omitted\nlines are not wrapped")
+	blockComments := flag.Bool("block-comments", false, "render documentation as /* */ block comments")
+	trailingComments := flag.Bool("trailing-comments", false,
+		"render single-line documentation as comments trailing the values")
+	blankLines := flag.String("blank-lines", "documented",
+		"blank lines between fields: documented, always or never; when omitted\nonly documented fields are separated")
 
 	flag.Parse()
 
@@ -56,6 +77,105 @@ func main() {
 		}
 	}
 
+	options := go2jsonc.Options{
+		DocTypes:           docMode,
+		NoSecretHeuristics: *noSecretHeuristics,
+		Indent:             *indent,
+		TabWidth:           *tabWidth,
+		LineWidth:          *lineWidth,
+		BlockComments:      *blockComments,
+		TrailingComments:   *trailingComments,
+	}
+
+	switch *deprecated {
+	case "comment":
+		options.Deprecated = go2jsonc.CommentDeprecated
+
+	case "omit":
+		options.Deprecated = go2jsonc.OmitDeprecated
+
+	case "render":
+		options.Deprecated = go2jsonc.RenderDeprecated
+
+	default:
+		fmt.Printf("Invalid value %s for -deprecated flag.\n\n", *deprecated)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	switch *examples {
+	case "live":
+		options.Examples = go2jsonc.LiveExamples
+
+	case "comment":
+		options.Examples = go2jsonc.CommentExamples
+
+	default:
+		fmt.Printf("Invalid value %s for -examples flag.\n\n", *examples)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	switch *typeStyle {
+	case "go":
+		options.Types = distiller.GoTypes
+
+	case "qualified":
+		options.Types = distiller.QualifiedGoTypes
+
+	case "json":
+		options.Types = distiller.JSONTypes
+
+	default:
+		fmt.Printf("Invalid value %s for -types flag.\n\n", *typeStyle)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	switch *naming {
+	case "as-is":
+		options.Naming = distiller.AsIsNames
+
+	case "lower-camel":
+		options.Naming = distiller.LowerCamelNames
+
+	case "snake":
+		options.Naming = distiller.SnakeCaseNames
+
+	case "kebab":
+		options.Naming = distiller.KebabCaseNames
+
+	case "screaming-snake":
+		options.Naming = distiller.ScreamingSnakeNames
+
+	default:
+		fmt.Printf("Invalid value %s for -naming flag.\n\n", *naming)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	switch *blankLines {
+	case "documented":
+		options.BlankLines = go2jsonc.BlankLinesDocumented
+
+	case "always":
+		options.BlankLines = go2jsonc.BlankLinesAlways
+
+	case "never":
+		options.BlankLines = go2jsonc.BlankLinesNever
+
+	default:
+		fmt.Printf("Invalid value %s for -blank-lines flag.\n\n", *blankLines)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *indent < 0 || *tabWidth < 1 || *lineWidth < 0 {
+		println("Flags -indent and -line-width cannot be negative, -tab-width must be positive.\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
 	dirs := flag.Args()
 
 	dir := "."
@@ -72,7 +192,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	code, err := go2jsonc.Generate(dir, *typeName, docMode)
+	code, err := go2jsonc.GenerateWithOptions(dir, *typeName, options)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -92,7 +212,8 @@ func usage() {
 	println("go2jsonc v" + version + " Copyright 2022-2023 Marco Sacchi\n")
 
 	println("Usage:")
-	println("  go2jsonc -type <type-name> [-doc-types bits] [-out jsonc-filename] [package-dir]\n")
+	println("  go2jsonc -type <type-name> [-doc-types bits] [-types style] [-naming strategy] [-deprecated mode] [-examples mode] [-no-secret-heuristics]\n" +
+		"           [-indent n] [-tab-width n] [-line-width n] [-block-comments] [-trailing-comments] [-blank-lines mode] [-out jsonc-filename] [package-dir]\n")
 
 	flag.PrintDefaults()
 
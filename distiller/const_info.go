@@ -1,8 +1,11 @@
 package distiller
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
 	"golang.org/x/tools/go/packages"
 	"strings"
@@ -11,19 +14,62 @@ import (
 // ConstInfo holds information about a typed constant.
 type ConstInfo struct {
 	Name  string // Constant name.
-	Value string // String representation of constant value.
+	Value string // Exact string representation of constant value, e.g. 1/3 for floats.
+	JSON  string // JSON literal the constant is encoded to, the text for types marshaled as text.
 	Doc   string // Constant documentation and comment nodes contents.
+
+	// The constant is marshaled as text that cannot be evaluated statically, JSON is empty.
+	TextUnknown bool
+
+	pkgPath  string         // Path of the package declaring the constant.
+	pos      token.Position // Position of the constant declaration.
+	pending  *types.Const   // Constant whose text is not resolved yet, nil if resolved or not marshaled as text.
+	shifted  bool           // Value is a left shift expression, e.g. 1 << iota, also implicitly repeated.
+	unsigned bool           // Type of the constant has an unsigned integer underlying type.
 }
 
 // NewConstInfo creates new const information object from given abstract syntax tree value spec and package.
 func NewConstInfo(valueSpec *ast.ValueSpec, pkg *packages.Package) *ConstInfo {
+	return newConstInfo(valueSpec.Names[0], valueSpec, pkg)
+}
+
+// newConstInfo creates new const information object for the named constant of given value spec,
+// which can declare more constants.
+func newConstInfo(name *ast.Ident, valueSpec *ast.ValueSpec, pkg *packages.Package) *ConstInfo {
+	obj := pkg.TypesInfo.ObjectOf(name).(*types.Const)
+	basic, _ := obj.Type().Underlying().(*types.Basic)
+	value := obj.Val()
 	return &ConstInfo{
-		Name:  valueSpec.Names[0].Name,
-		Value: pkg.TypesInfo.ObjectOf(valueSpec.Names[0]).(*types.Const).Val().ExactString(),
-		Doc:   valueSpec.Doc.Text() + valueSpec.Comment.Text(),
+		Name:     name.Name,
+		Value:    value.ExactString(),
+		JSON:     ConstantJSON(value),
+		Doc:      valueSpec.Doc.Text() + valueSpec.Comment.Text(),
+		pkgPath:  pkg.PkgPath,
+		pos:      pkg.Fset.Position(name.Pos()),
+		unsigned: basic != nil && basic.Info()&types.IsUnsigned != 0,
 	}
 }
 
+// ConstantJSON returns the JSON literal encoding the constant value v, as encoding/json does for
+// values of its type: strings are quoted and escaped, floats are in the shortest form, with an
+// exponent only if very small or large.
+func ConstantJSON(v constant.Value) string {
+	switch v.Kind() {
+	case constant.String:
+		quoted, _ := json.Marshal(constant.StringVal(v))
+		return string(quoted)
+
+	case constant.Float:
+		// Constants overflowing float64 are not encodable, the exact value is returned.
+		f, _ := constant.Float64Val(v)
+		if encoded, err := json.Marshal(f); err == nil {
+			return string(encoded)
+		}
+	}
+
+	return v.ExactString()
+}
+
 // String implements the stringer interface.
 func (c *ConstInfo) String() string {
 	return fmt.Sprintf("Name: \"%s\"\nValue: %s\nDoc: \"%s\"\n",
@@ -35,3 +81,13 @@ func (c *ConstInfo) String() string {
 func (c *ConstInfo) InlineDoc() string {
 	return strings.TrimRight(strings.ReplaceAll(c.Doc, "\n", " "), " ")
 }
+
+// jsonDoc returns the JSON literal of the constant as written in documentation, text unknown if
+// the text it is marshaled to cannot be evaluated.
+func (c *ConstInfo) jsonDoc() string {
+	if c.TextUnknown {
+		return "text unknown"
+	}
+
+	return c.JSON
+}
@@ -3,6 +3,7 @@ package distiller
 import (
 	"github.com/marco-sacchi/go2jsonc/testutils"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"golang.org/x/tools/go/packages"
 	"strings"
@@ -33,6 +34,31 @@ func TestNewConstInfo(t *testing.T) {
 	}
 }
 
+func TestConstantJSON(t *testing.T) {
+	tests := []struct {
+		value constant.Value
+		want  string
+	}{
+		{constant.MakeInt64(-42), "-42"},
+		{constant.MakeBool(true), "true"},
+		{constant.MakeString("fast"), `"fast"`},
+		{constant.MakeString("a \"quoted\"\tvalue\u2028"), `"a \"quoted\"\tvalue\u2028"`},
+		{constant.MakeFloat64(0.5), "0.5"},
+		{constant.BinaryOp(constant.MakeInt64(1), token.QUO, constant.MakeInt64(3)), "0.3333333333333333"},
+		{constant.MakeFloat64(1234567.5), "1234567.5"},
+		{constant.MakeFloat64(1e20), "100000000000000000000"},
+		{constant.MakeFloat64(1e21), "1e+21"},
+		{constant.MakeFloat64(0.000001), "0.000001"},
+		{constant.MakeFloat64(1e-7), "1e-7"},
+	}
+
+	for _, test := range tests {
+		if got := ConstantJSON(test.value); got != test.want {
+			t.Fatalf("JSON literal mismatch for %s: got %s, want %s", test.value.ExactString(), got, test.want)
+		}
+	}
+}
+
 func TestConstInfo_String(t *testing.T) {
 	pkgs := testutils.LoadPackage(t, "../testdata/consts.go")
 	consts := getConsts(pkgs)
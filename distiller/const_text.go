@@ -0,0 +1,354 @@
+package distiller
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+)
+
+// resolveText sets the JSON of the constant to the text it is marshaled to, evaluated in the package
+// declaring its type. The text is unknown if it cannot be evaluated or the package is not loaded.
+func (c *ConstInfo) resolveText(declaring *PackageInfo) {
+	text, ok := "", false
+	if declaring != nil {
+		text, ok = declaring.constText(c.pending)
+	}
+
+	c.pending = nil
+	if !ok {
+		c.JSON = ""
+		c.TextUnknown = true
+		return
+	}
+
+	// encoding/json marshals the text as string.
+	quoted, _ := json.Marshal(text)
+	c.JSON = string(quoted)
+}
+
+// constText returns the text constant c is marshaled to by the MarshalText method of its type, evaluated
+// statically. Switches on the receiver, lookup of arrays, slices and maps indexed by the receiver,
+// substrings and calls to the String method are resolved, as in String methods generated by stringer.
+// It returns false if the text cannot be determined.
+func (p *PackageInfo) constText(c *types.Const) (string, bool) {
+	named, ok := types.Unalias(c.Type()).(*types.Named)
+	if !ok {
+		return "", false
+	}
+
+	return p.methodText(named.Obj(), "MarshalText", c.Val(), 0)
+}
+
+// maxTextCalls limits the nesting of method calls evaluated by methodText.
+const maxTextCalls = 4
+
+// methodText evaluates the string returned by the named method of typeName for the receiver value.
+// Only the switch and return statements at the top level of the method body are evaluated; other
+// statements are skipped, unless assigning the receiver.
+func (p *PackageInfo) methodText(typeName *types.TypeName, method string, value constant.Value,
+	calls int) (string, bool) {
+	decl := p.lookupMethodDecl(typeName, method)
+	if decl == nil || decl.Body == nil || calls >= maxTextCalls {
+		return "", false
+	}
+
+	var recv types.Object
+	if names := decl.Recv.List[0].Names; len(names) == 1 {
+		recv = p.Package.TypesInfo.Defs[names[0]]
+	}
+
+	for _, stmt := range decl.Body.List {
+		switch s := stmt.(type) {
+		case *ast.SwitchStmt:
+			if s.Init != nil || !p.refersTo(s.Tag, recv) {
+				continue
+			}
+
+			var defaultBody []ast.Stmt
+			for _, clause := range s.Body.List {
+				clause := clause.(*ast.CaseClause)
+				if clause.List == nil {
+					defaultBody = clause.Body
+					continue
+				}
+
+				for _, expr := range clause.List {
+					if v := p.Package.TypesInfo.Types[expr].Value; v != nil && constant.Compare(v, token.EQL, value) {
+						return p.returnedText(clause.Body, typeName, recv, value, calls)
+					}
+				}
+			}
+
+			if defaultBody != nil {
+				return p.returnedText(defaultBody, typeName, recv, value, calls)
+			}
+
+		case *ast.ReturnStmt:
+			if len(s.Results) == 0 {
+				return "", false
+			}
+
+			return p.exprText(s.Results[0], typeName, recv, value, calls)
+
+		case *ast.AssignStmt:
+			// The receiver value would differ in the following statements, e.g. i -= 1.
+			for _, lhs := range s.Lhs {
+				if p.refersTo(lhs, recv) {
+					return "", false
+				}
+			}
+
+		case *ast.IncDecStmt:
+			if p.refersTo(s.X, recv) {
+				return "", false
+			}
+		}
+	}
+
+	return "", false
+}
+
+// returnedText evaluates the string returned by the first statement of body, if a return statement.
+func (p *PackageInfo) returnedText(body []ast.Stmt, typeName *types.TypeName, recv types.Object,
+	value constant.Value, calls int) (string, bool) {
+	if len(body) == 0 {
+		return "", false
+	}
+
+	ret, ok := body[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) == 0 {
+		return "", false
+	}
+
+	return p.exprText(ret.Results[0], typeName, recv, value, calls)
+}
+
+// exprText evaluates the string value of expr for the receiver value. Supported expressions are string
+// constants, conversions to string or []byte, calls to the String method of the receiver, substrings
+// and indexing by the receiver of composite literals and package variables initialized by them.
+func (p *PackageInfo) exprText(expr ast.Expr, typeName *types.TypeName, recv types.Object,
+	value constant.Value, calls int) (string, bool) {
+	info := p.Package.TypesInfo
+	if v := info.Types[expr].Value; v != nil {
+		if v.Kind() != constant.String {
+			return "", false
+		}
+
+		return constant.StringVal(v), true
+	}
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return p.exprText(e.X, typeName, recv, value, calls)
+
+	case *ast.CallExpr:
+		// Conversions, e.g. []byte(s).
+		if tv, ok := info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return p.exprText(e.Args[0], typeName, recv, value, calls)
+		}
+
+		selector, ok := e.Fun.(*ast.SelectorExpr)
+		if ok && len(e.Args) == 0 && selector.Sel.Name == "String" && p.refersTo(selector.X, recv) {
+			return p.methodText(typeName, "String", value, calls+1)
+		}
+
+	case *ast.IndexExpr:
+		if elt := p.indexedElt(e, recv, value); elt != nil {
+			return p.exprText(elt, typeName, recv, value, calls)
+		}
+
+	case *ast.SliceExpr:
+		text, ok := p.exprText(e.X, typeName, recv, value, calls)
+		if !ok || e.Slice3 {
+			return "", false
+		}
+
+		low, lowOk := p.sliceBound(e.Low, recv, value, 0)
+		high, highOk := p.sliceBound(e.High, recv, value, len(text))
+		if !lowOk || !highOk || low < 0 || low > high || high > len(text) {
+			return "", false
+		}
+
+		return text[low:high], true
+	}
+
+	return "", false
+}
+
+// sliceBound evaluates the bound of a slice expression for the receiver value, def if omitted.
+func (p *PackageInfo) sliceBound(expr ast.Expr, recv types.Object, value constant.Value, def int) (int, bool) {
+	if expr == nil {
+		return def, true
+	}
+
+	v := p.exprValue(expr, recv, value)
+	if v == nil {
+		return 0, false
+	}
+
+	n, exact := constant.Int64Val(constant.ToInt(v))
+	return int(n), exact
+}
+
+// exprValue evaluates the constant value of expr for the receiver value. Supported expressions are
+// constants, the receiver, additions, subtractions and multiplications, and indexing by the receiver
+// of composite literals and package variables initialized by them. It returns nil if not evaluable.
+func (p *PackageInfo) exprValue(expr ast.Expr, recv types.Object, value constant.Value) constant.Value {
+	if v := p.Package.TypesInfo.Types[expr].Value; v != nil {
+		return v
+	}
+
+	if p.refersTo(expr, recv) {
+		return value
+	}
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return p.exprValue(e.X, recv, value)
+
+	case *ast.CallExpr:
+		if tv, ok := p.Package.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return p.exprValue(e.Args[0], recv, value)
+		}
+
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD && e.Op != token.SUB && e.Op != token.MUL {
+			return nil
+		}
+
+		x, y := p.exprValue(e.X, recv, value), p.exprValue(e.Y, recv, value)
+		if x == nil || y == nil {
+			return nil
+		}
+
+		return constant.BinaryOp(x, e.Op, y)
+
+	case *ast.IndexExpr:
+		if elt := p.indexedElt(e, recv, value); elt != nil {
+			return p.exprValue(elt, recv, value)
+		}
+	}
+
+	return nil
+}
+
+// indexedElt returns the element of the composite literal, or of the one initializing the package
+// variable, indexed in e, nil if the index cannot be evaluated or the element is not found.
+func (p *PackageInfo) indexedElt(e *ast.IndexExpr, recv types.Object, value constant.Value) ast.Expr {
+	index := p.exprValue(e.Index, recv, value)
+	lit := p.compositeLit(e.X)
+	if index == nil || lit == nil {
+		return nil
+	}
+
+	// Positional elements follow the index of the previous one, keyed or not.
+	i := constant.MakeInt64(0)
+	for _, elt := range lit.Elts {
+		if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+			key := p.Package.TypesInfo.Types[keyValue.Key].Value
+			if key == nil {
+				return nil
+			}
+
+			i, elt = key, keyValue.Value
+		}
+
+		if constant.Compare(i, token.EQL, index) {
+			return elt
+		}
+
+		i = constant.BinaryOp(i, token.ADD, constant.MakeInt64(1))
+	}
+
+	return nil
+}
+
+// refersTo reports whether expr refers to the receiver object, also through parentheses, dereferences
+// and conversions.
+func (p *PackageInfo) refersTo(expr ast.Expr, recv types.Object) bool {
+	if recv == nil {
+		return false
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return p.Package.TypesInfo.Uses[e] == recv
+
+	case *ast.ParenExpr:
+		return p.refersTo(e.X, recv)
+
+	case *ast.StarExpr:
+		return p.refersTo(e.X, recv)
+
+	case *ast.CallExpr:
+		if tv, ok := p.Package.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return p.refersTo(e.Args[0], recv)
+		}
+	}
+
+	return false
+}
+
+// compositeLit returns the composite literal expr is, or the one initializing the package variable
+// expr refers to. It returns nil if not found.
+func (p *PackageInfo) compositeLit(expr ast.Expr) *ast.CompositeLit {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e
+
+	case *ast.ParenExpr:
+		return p.compositeLit(e.X)
+
+	case *ast.Ident:
+		variable, ok := p.Package.TypesInfo.Uses[e].(*types.Var)
+		if !ok {
+			return nil
+		}
+
+		for _, file := range p.Package.Syntax {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.VAR {
+					continue
+				}
+
+				for _, spec := range genDecl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					for i, name := range valueSpec.Names {
+						if p.Package.TypesInfo.Defs[name] == variable && i < len(valueSpec.Values) {
+							return p.compositeLit(valueSpec.Values[i])
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// lookupMethodDecl returns the declaration of the named method of typeName, nil if not declared
+// in the package.
+func (p *PackageInfo) lookupMethodDecl(typeName *types.TypeName, method string) *ast.FuncDecl {
+	for _, file := range p.Package.Syntax {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || funcDecl.Name.Name != method {
+				continue
+			}
+
+			recvType := funcDecl.Recv.List[0].Type
+			if star, ok := recvType.(*ast.StarExpr); ok {
+				recvType = star.X
+			}
+
+			if ident, ok := recvType.(*ast.Ident); ok && p.Package.TypesInfo.Uses[ident] == typeName {
+				return funcDecl
+			}
+		}
+	}
+
+	return nil
+}
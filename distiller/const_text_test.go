@@ -0,0 +1,41 @@
+package distiller
+
+import (
+	"testing"
+)
+
+func TestPackageInfo_constText(t *testing.T) {
+	pkgInfo, err := NewPackageInfo("../testdata/enums", "Enums")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const path = "github.com/marco-sacchi/go2jsonc/testdata/enums."
+	tests := map[string][]string{
+		"Level":    {`"debug"`, `"info"`, `"error"`},
+		"Protocol": {`"tcp"`, `"udp"`, `"quic"`},
+		"Color":    {`"Red"`, `"Green"`, `"Blue"`},
+		"Severity": {"", ""},
+		"Mode":     {"0", "1", "2"},
+		"Speed":    {`"fast"`, `"slow"`, `"plaid \"max\""`},
+		"Ratio":    {"0", "0.3333333333333333", "0.5", "1"},
+		"Toggle":   {"true", "false"},
+	}
+
+	for typeName, want := range tests {
+		consts := pkgInfo.TypedConsts[path+typeName]
+		if len(consts) != len(want) {
+			t.Fatalf("Constants count mismatch for type %s: got %d, want %d", typeName, len(consts), len(want))
+		}
+
+		for i, info := range consts {
+			if info.TextUnknown != (want[i] == "") {
+				t.Fatalf("Text unknown mismatch for constant %s: got %v", info.Name, info.TextUnknown)
+			}
+
+			if info.JSON != want[i] {
+				t.Fatalf("JSON value mismatch for constant %s: got %s, want %s", info.Name, info.JSON, want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package distiller
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// directivePrefix prefixes go2jsonc directives in comments, e.g.:
+//
+//	//go2jsonc:oneof=FileSink,HTTPSink
+//
+// A space between the comment marker and the prefix is also accepted.
+const directivePrefix = "go2jsonc:"
+
+// Directives recognized on fields and, to apply them to all fields of the struct type, on structs.
+const (
+	DirectiveIgnore     = "ignore"     // Omits the field.
+	DirectiveName       = "name"       // Overrides the key name, json tag included. Fields only.
+	DirectiveExample    = "example"    // JSON literal rendered when no default value is defined.
+	DirectiveDeprecated = "deprecated" // Marks the field as deprecated, the value is the deprecation note.
+	DirectiveCollapse   = "collapse"   // Renders the value on a single line, without comments.
+	DirectiveRequired   = "required"   // Marks the field as required.
+	DirectiveSecret     = "secret"     // Renders a placeholder instead of the default value.
+	DirectiveOneOf      = "oneof"      // Lists the implementations of interface fields. Fields only.
+	DirectiveType       = "type"       // Shows or hides the type in documentation, the value is show or hide.
+)
+
+// parseDirectives extracts the go2jsonc directives from given comment groups as map of names to
+// values, the value is an empty string for directives without value. It returns nil if no
+// directives are found.
+func parseDirectives(groups ...*ast.CommentGroup) map[string]string {
+	var directives map[string]string
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, comment := range group.List {
+			text := strings.TrimPrefix(strings.TrimPrefix(comment.Text, "//"), " ")
+			if !strings.HasPrefix(text, directivePrefix) {
+				continue
+			}
+
+			name, value, _ := strings.Cut(strings.TrimSpace(text[len(directivePrefix):]), "=")
+			if directives == nil {
+				directives = make(map[string]string)
+			}
+
+			directives[name] = value
+		}
+	}
+
+	return directives
+}
+
+// Directive returns the value of the named directive of the field and whether it is set. Directives
+// not set on the field are inherited from its struct type, or the struct type of its elements, except
+// for name and oneof.
+func (f *FieldInfo) Directive(name string) (string, bool) {
+	if value, ok := f.Directives[name]; ok {
+		return value, true
+	}
+
+	if name == DirectiveName || name == DirectiveOneOf {
+		return "", false
+	}
+
+	t := f.Type
+	if f.EltType != nil {
+		t = f.EltType
+	}
+
+	if pointer, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	if s := LookupStructType(t); s != nil {
+		value, ok := s.Directives[name]
+		return value, ok
+	}
+
+	return "", false
+}
+
+// stripDirectives removes directive lines from a documentation text. Directives without space
+// after the comment marker are already removed by ast.CommentGroup.Text.
+func stripDirectives(doc string) string {
+	if !strings.Contains(doc, directivePrefix) {
+		return doc
+	}
+
+	var builder strings.Builder
+	for _, line := range strings.SplitAfter(doc, "\n") {
+		if !strings.HasPrefix(line, directivePrefix) {
+			builder.WriteString(line)
+		}
+	}
+
+	return builder.String()
+}
@@ -24,11 +24,16 @@ type FieldInfo struct {
 	Name       string            // Field name.
 	Layout     FieldLayout       // Field layout.
 	EltType    types.Type        // Field element type, when the field is a slice or map.
+	KeyType    types.Type        // Field key type, when the field is a map.
 	IsEmbedded bool              // True if field is an embedded struct and Name is an empty string.
 	Tags       map[string]string // Tags applied to that field as map of name-value key-pairs.
 	Doc        string            // Documentation content if present.
+	Directives map[string]string // Directives in documentation as map of name-value pairs, nil if none.
 }
 
+// inlineStructs maps the types of anonymous structs declared inline to their information.
+var inlineStructs = make(map[*types.Struct]*StructInfo)
+
 // tagRegexp defines a regex to extract tags names and values.
 var tagRegexp = regexp.MustCompile(`(\w+):"((?:[^"\\]|\\.)*)"`)
 
@@ -46,17 +51,21 @@ func NewFieldInfo(field *ast.Field, pkg *packages.Package) *FieldInfo {
 	}
 
 	f.Type = pkg.TypesInfo.Types[field.Type].Type
-	switch fieldType := field.Type.(type) {
-	case *ast.ArrayType:
-		// In case of array get the type of single element.
-		f.EltType = pkg.TypesInfo.Types[fieldType.Elt].Type
-		f.Layout = LayoutArray
+	f.Layout, f.KeyType, f.EltType = layoutOf(f.Type)
 
-	case *ast.MapType:
-		// In case of map get the type of value.
-		f.EltType = pkg.TypesInfo.Types[fieldType.Value].Type
-		f.Layout = LayoutMap
-	}
+	// Synthesize the information of anonymous structs declared inline, also as element types.
+	ast.Inspect(field.Type, func(node ast.Node) bool {
+		structType, ok := node.(*ast.StructType)
+		if !ok {
+			return true
+		}
+
+		inline := newInlineStructInfo(structType, pkg)
+		inlineStructs[inline.inlineType] = inline
+
+		// Nested inline structs are handled by the fields of this one.
+		return false
+	})
 
 	// Parse defined tags populating FieldInfo.Tags map.
 	if field.Tag != nil {
@@ -72,25 +81,176 @@ func NewFieldInfo(field *ast.Field, pkg *packages.Package) *FieldInfo {
 	}
 
 	// Merge documentation and comment.
-	f.Doc = field.Doc.Text() + field.Comment.Text()
+	f.Doc = stripDirectives(field.Doc.Text() + field.Comment.Text())
+	f.Directives = parseDirectives(field.Doc, field.Comment)
 	return f
 }
 
+// layoutOf returns the layout of a field of given type and, for slices, arrays and maps, the key and
+// element types. Aliases and named types are resolved to the underlying type, unless they have
+// a dedicated JSON representation, e.g. net.IP.
+func layoutOf(t types.Type) (layout FieldLayout, keyType types.Type, eltType types.Type) {
+	t = types.Unalias(t)
+	if _, ok := t.(*types.Named); ok && (IsWellKnownType(t) || MarshalerMethod(t) != "") {
+		return LayoutSingle, nil, nil
+	}
+
+	switch typ := t.Underlying().(type) {
+	case *types.Slice:
+		return LayoutArray, nil, typ.Elem()
+
+	case *types.Array:
+		return LayoutArray, nil, typ.Elem()
+
+	case *types.Map:
+		return LayoutMap, typ.Key(), typ.Elem()
+	}
+
+	return LayoutSingle, nil, nil
+}
+
+// DerefType returns the type pointed by t, or t itself if it is not a pointer, resolving aliases.
+func DerefType(t types.Type) types.Type {
+	t = types.Unalias(t)
+	if pointer, ok := t.(*types.Pointer); ok {
+		return types.Unalias(pointer.Elem())
+	}
+
+	return t
+}
+
 func (f *FieldInfo) String() string {
 	return fmt.Sprintf("Type: %s\nName: \"%s\"\nLayout: %v\nElement type: %v\nIsEmbedded: %v\nTags: %+v\nDoc: \"%v\"\n",
 		f.Type.String(), f.Name, f.Layout, f.EltType,
 		f.IsEmbedded, f.Tags, strings.ReplaceAll(f.Doc, "\n", "\\n"))
 }
 
+// IsRequired reports whether the field is marked as required by the required directive or option
+// of the validate tag.
+func (f *FieldInfo) IsRequired() bool {
+	if _, ok := f.Directive(DirectiveRequired); ok {
+		return true
+	}
+
+	_, ok := f.validationOptions()["required"]
+	return ok
+}
+
+// IsOptional reports whether the field can be omitted, being not required and either a pointer or
+// tagged as omitempty.
+func (f *FieldInfo) IsOptional() bool {
+	if f.IsRequired() {
+		return false
+	}
+
+	if _, ok := types.Unalias(f.Type).(*types.Pointer); ok {
+		return true
+	}
+
+	_, options, _ := strings.Cut(f.Tags["json"], ",")
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" || option == "omitzero" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsDeprecated reports whether the field is marked as deprecated by the deprecated directive or
+// a documentation paragraph starting with "Deprecated: ", as by Go convention.
+func (f *FieldInfo) IsDeprecated() bool {
+	if _, ok := f.Directive(DirectiveDeprecated); ok {
+		return true
+	}
+
+	paragraphStart := true
+	for _, line := range strings.Split(f.Doc, "\n") {
+		if paragraphStart && strings.HasPrefix(line, "Deprecated: ") {
+			return true
+		}
+
+		paragraphStart = strings.TrimSpace(line) == ""
+	}
+
+	return false
+}
+
+// DocFormat controls the formatting of documentation comments.
+type DocFormat struct {
+	LineWidth int       // Maximum width of lines, longer lines are wrapped; no limit when zero.
+	TabWidth  int       // Width of tabs in the indentation, 4 when zero.
+	Types     TypeStyle // Style of the field type.
+}
+
 // FormatDoc formats the field documentation indenting it with passed indent string.
 func (f *FieldInfo) FormatDoc(indent string, renderType bool) string {
+	return f.FormatDocWith(indent, renderType, DocFormat{})
+}
+
+// FormatDocWith formats the field documentation indenting it with passed indent string, wrapping lines
+// as specified by format. The allowed values of typed constants are not wrapped to keep them aligned.
+func (f *FieldInfo) FormatDocWith(indent string, renderType bool, format DocFormat) string {
 	doc := f.Doc
+	if format.LineWidth > 0 {
+		doc = joinParagraphs(doc)
+	}
+
+	if note, ok := f.Directive(DirectiveDeprecated); ok {
+		if note != "" {
+			doc += "Deprecated: " + note + "\n"
+		} else {
+			doc += "Deprecated.\n"
+		}
+	}
+
+	if f.IsRequired() {
+		doc += "Required.\n"
+	} else if f.IsOptional() {
+		doc += "Optional.\n"
+	}
+
+	// Invalid tags are reported on rendering.
+	if jsoncTag, _ := f.JSONCTag(); jsoncTag != nil && jsoncTag.Range != "" {
+		minValue, maxValue, _ := strings.Cut(jsoncTag.Range, "..")
+		doc += rangeNote(minValue, maxValue) + "\n"
+	}
+
+	for _, note := range f.validationNotes() {
+		doc += note + "\n"
+	}
+
+	fieldType := types.Unalias(f.Type)
+	if pointer, ok := fieldType.(*types.Pointer); ok {
+		fieldType = types.Unalias(pointer.Elem())
+	}
+
+	// Explain the format of types having a dedicated JSON representation, also for elements
+	// of slices and maps.
+	formatType := fieldType
+	if f.EltType != nil {
+		formatType = types.Unalias(f.EltType)
+		if pointer, ok := formatType.(*types.Pointer); ok {
+			formatType = types.Unalias(pointer.Elem())
+		}
+	}
+
+	if format := WellKnownFormat(formatType); format != "" {
+		doc += format + "\n"
+	} else if method := MarshalerMethod(formatType); method != "" && LookupTypedConsts(formatType.String()) == nil {
+		doc += fmt.Sprintf("Encoded as string by %s.%s.\n", shortTypeString(formatType), method)
+	}
 
 	// Check if the type is used to define typed constants.
-	consts := LookupTypedConsts(f.Type.String())
-	if consts != nil {
+	table := ""
+	consts := LookupTypedConsts(fieldType.String())
+	if consts != nil && !IsWellKnownType(fieldType) {
 		// Display allowed values for defined constants below the field documentation.
-		doc += "Allowed values:\n"
+		if IsFlagSet(consts) {
+			doc += "Flags (combinable):\n"
+		} else {
+			doc += "Allowed values:\n"
+		}
 
 		constLen := 0
 		valueLen := 0
@@ -98,47 +258,121 @@ func (f *FieldInfo) FormatDoc(indent string, renderType bool) string {
 			if len(info.Name) > constLen {
 				constLen = len(info.Name)
 			}
-			if len(info.Value) > valueLen {
-				valueLen = len(info.Value)
+			if len(info.jsonDoc()) > valueLen {
+				valueLen = len(info.jsonDoc())
 			}
 		}
 
+		// Numbers are aligned to the right, strings and booleans to the left.
+		if !isJSONNumber(consts[0].JSON) {
+			valueLen = -valueLen
+		}
+
 		for _, info := range consts {
-			doc += fmt.Sprintf("%-*s = %*v  %s\n", constLen, info.Name, valueLen, info.Value, info.InlineDoc())
+			table += fmt.Sprintf("%-*s = %*v  %s\n", constLen, info.Name, valueLen, info.jsonDoc(), info.InlineDoc())
+		}
+	}
+
+	if renderType {
+		typeName := typeString(f.Type, format.Types)
+		if doc != "" {
+			typeName += " - "
+		} else {
+			typeName += "\n"
 		}
+
+		doc = typeName + doc
+	}
+
+	if doc == "" {
+		return ""
 	}
 
-	// Indent the documentation.
 	commentPrefix := indent + "// "
-	d := strings.ReplaceAll(doc, "\n", "\n"+commentPrefix)
-	if len(d) > 0 {
-		d = d[:len(d)-len(commentPrefix)]
-	} else {
-		d = "\n"
+	if format.LineWidth > 0 {
+		tabWidth := format.TabWidth
+		if tabWidth == 0 {
+			tabWidth = 4
+		}
+
+		prefixWidth := len(commentPrefix) + strings.Count(indent, "\t")*(tabWidth-1)
+		doc = wrapLines(doc, format.LineWidth-prefixWidth)
 	}
 
-	typeName := ""
+	// Indent the documentation, blank lines separating paragraphs have no trailing space.
+	var builder strings.Builder
+	for _, line := range strings.SplitAfter(doc+table, "\n") {
+		if line == "" {
+			continue
+		}
 
-	if renderType {
-		typeName = f.Type.String()
-		if lastSlash := strings.LastIndex(typeName, "/"); lastSlash >= 0 {
-			typeName = typeName[lastSlash+1:]
-			// The square brackets at the beginning of the typeName are trimmed out, so must be re-added.
-			if f.Layout == LayoutArray {
-				typeName = "[]" + typeName
-			}
+		if line == "\n" {
+			builder.WriteString(strings.TrimSuffix(commentPrefix, " ") + line)
+		} else {
+			builder.WriteString(commentPrefix + line)
+		}
+	}
+
+	return builder.String()
+}
+
+// joinParagraphs joins the lines of each paragraph of text so that they can be wrapped again.
+// Indented lines, e.g. code blocks, and list items are kept on their own lines.
+func joinParagraphs(text string) string {
+	var builder strings.Builder
+	joinable := false
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
 		}
 
-		if d != "\n" {
-			typeName += " - "
+		content := strings.TrimSuffix(line, "\n")
+		keep := content == "" || strings.HasPrefix(content, " ") || strings.HasPrefix(content, "\t") ||
+			strings.HasPrefix(content, "- ") || strings.HasPrefix(content, "* ")
+
+		if joinable && !keep {
+			// Replaces the newline of the previous line.
+			str := strings.TrimSuffix(builder.String(), "\n")
+			builder.Reset()
+			builder.WriteString(str + " ")
 		}
 
-		d = typeName + d
+		builder.WriteString(line)
+		joinable = content != "" && !strings.HasPrefix(content, " ") && !strings.HasPrefix(content, "\t")
 	}
 
-	if d == "\n" {
-		return ""
+	return builder.String()
+}
+
+// isJSONNumber reports whether literal is a JSON number.
+func isJSONNumber(literal string) bool {
+	return literal != "" && (literal[0] == '-' || (literal[0] >= '0' && literal[0] <= '9'))
+}
+
+// wrapLines wraps the lines of text longer than width at spaces. Words longer than width are not split.
+func wrapLines(text string, width int) string {
+	// Keeps a minimum width for deeply indented comments.
+	if width < 20 {
+		width = 20
+	}
+
+	var builder strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for len(strings.TrimSuffix(line, "\n")) > width {
+			end := strings.LastIndex(line[:width+1], " ")
+			if end <= 0 {
+				end = strings.Index(line, " ")
+				if end < 0 || end >= len(strings.TrimSuffix(line, "\n")) {
+					break
+				}
+			}
+
+			builder.WriteString(line[:end] + "\n")
+			line = strings.TrimLeft(line[end:], " ")
+		}
+
+		builder.WriteString(line)
 	}
 
-	return commentPrefix + d
+	return builder.String()
 }
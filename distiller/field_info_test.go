@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/marco-sacchi/go2jsonc/testutils"
 	"go/ast"
+	"go/types"
 	"reflect"
 	"strings"
 	"testing"
@@ -613,3 +614,180 @@ func getFieldsInfo(t *testing.T, patterns []string) []*FieldInfo {
 	}
 	return fields
 }
+
+func TestFieldInfo_Directives(t *testing.T) {
+	fields := getFieldsInfo(t, []string{"../testdata/plugins"})
+
+	want := []struct {
+		name       string
+		doc        string
+		directives map[string]string
+	}{
+		{name: "Path", doc: "File path.\n"},
+		{name: "Append", doc: "Append to existing file.\n"},
+		{name: "URL", doc: "Endpoint URL.\n"},
+		{name: "Retries", doc: "Retries count.\n"},
+		{name: "Output", doc: "Main output, all implementations are listed.\n"},
+		{name: "Audit", doc: "Audit output.\n", directives: map[string]string{"oneof": "FileSink"}},
+		{name: "Remote", doc: "Remote output.\n", directives: map[string]string{"oneof": "HTTPSink"}},
+		{name: "Sinks", doc: "Additional sinks.\n"},
+		{name: "Extra", doc: "Free-form extra data.\n"},
+		{name: "Meta", doc: "Free-form metadata.\n"},
+	}
+
+	// Interface methods and their parameters are parsed as fields too, so lookup by name.
+	byName := make(map[string]*FieldInfo)
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	for _, w := range want {
+		field, ok := byName[w.name]
+		if !ok {
+			t.Fatalf("Missing field %s", w.name)
+		}
+
+		if field.Doc != w.doc || !reflect.DeepEqual(field.Directives, w.directives) {
+			t.Fatalf("Parsed field mismatch:\n%s\nDirectives: %v\n\nwant doc %q, directives %v",
+				field, field.Directives, w.doc, w.directives)
+		}
+	}
+}
+
+func TestFieldInfo_Directive(t *testing.T) {
+	info, err := NewPackageInfo("../testdata/directives", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := info.Structs[info.Package.PkgPath+".Credentials"]
+	if credentials.Doc != "Credentials defines access credentials.\n" ||
+		!reflect.DeepEqual(credentials.Directives, map[string]string{"secret": ""}) {
+		t.Fatalf("Parsed struct mismatch:\n%s\nDirectives: %v", credentials, credentials.Directives)
+	}
+
+	want := []struct {
+		field     string
+		directive string
+		value     string
+		ok        bool
+	}{
+		{"State", DirectiveIgnore, "", true},
+		{"Port", DirectiveExample, "9090", true},
+		{"Password", DirectiveName, "db_password", true},
+		{"Password", DirectiveSecret, "", true},
+		{"Admin", DirectiveSecret, "", true},
+		{"Admin", DirectiveCollapse, "", false},
+		{"Path", DirectiveCollapse, "", true},
+		{"Legacy", DirectiveDeprecated, "settings are ignored since version 2.", true},
+		{"Timeout", DirectiveDeprecated, "", true},
+		{"Timeout", DirectiveSecret, "", false},
+	}
+
+	directives := info.Structs[info.Package.PkgPath+".Directives"]
+	for _, w := range want {
+		var field *FieldInfo
+		for _, f := range directives.Fields {
+			if f.Name == w.field {
+				field = f
+			}
+		}
+
+		if value, ok := field.Directive(w.directive); value != w.value || ok != w.ok {
+			t.Fatalf("Field %s directive %s mismatch: got %q, %v, want %q, %v",
+				w.field, w.directive, value, ok, w.value, w.ok)
+		}
+	}
+}
+
+func TestFieldInfo_IsOptional(t *testing.T) {
+	info, err := NewPackageInfo("../testdata/required", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][2]bool{
+		"Database.DSN":      {true, false},
+		"Database.PoolSize": {false, true},
+		"Backend.Address":   {true, false},
+		"Backend.Weight":    {false, true},
+		"Required.Name":     {true, false},
+		"Required.Database": {false, false},
+		"Required.Debug":    {false, true},
+	}
+
+	for name, w := range want {
+		structName, fieldName, _ := strings.Cut(name, ".")
+		for _, field := range info.Structs[info.Package.PkgPath+"."+structName].Fields {
+			if field.Name != fieldName {
+				continue
+			}
+
+			if field.IsRequired() != w[0] || field.IsOptional() != w[1] {
+				t.Fatalf("Field %s required, optional mismatch: got %v, %v, want %v, %v",
+					name, field.IsRequired(), field.IsOptional(), w[0], w[1])
+			}
+		}
+	}
+}
+
+func TestFieldInfo_IsDeprecated(t *testing.T) {
+	tests := []struct {
+		doc        string
+		directives map[string]string
+		want       bool
+	}{
+		{doc: "Server host.\n\nDeprecated: use hosts instead.\n", want: true},
+		{doc: "Deprecated: use hosts instead.\n", want: true},
+		{doc: "Server host.\nDeprecated: not a paragraph.\n", want: false},
+		{doc: "Server host. Deprecated: not a paragraph.\n", want: false},
+		{doc: "Server host.\n", directives: map[string]string{DirectiveDeprecated: ""}, want: true},
+		{doc: "", want: false},
+	}
+
+	for _, test := range tests {
+		field := &FieldInfo{Type: types.Typ[types.String], Doc: test.doc, Directives: test.directives}
+		if field.IsDeprecated() != test.want {
+			t.Fatalf("Field with doc %q deprecated mismatch: got %v, want %v", test.doc, !test.want, test.want)
+		}
+	}
+}
+
+func TestWrapLines(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"Short line.\n", 40, "Short line.\n"},
+		{"Logging level of the service, lower levels are discarded.\n", 30,
+			"Logging level of the service,\nlower levels are discarded.\n"},
+		{"A https://example.com/a/very/long/url/to/documentation line.\n", 20,
+			"A\nhttps://example.com/a/very/long/url/to/documentation\nline.\n"},
+		{"Narrow widths are raised to a minimum.\n", 5, "Narrow widths are\nraised to a minimum.\n"},
+	}
+
+	for _, test := range tests {
+		if got := wrapLines(test.text, test.width); got != test.want {
+			t.Fatalf("Wrapping %q to %d mismatch: got %q, want %q", test.text, test.width, got, test.want)
+		}
+	}
+}
+
+func TestJoinParagraphs(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"First line\nsecond line.\n", "First line second line.\n"},
+		{"First paragraph.\n\nSecond paragraph\ncontinued.\n", "First paragraph.\n\nSecond paragraph continued.\n"},
+		{"Values:\n- first\n- second\n", "Values:\n- first\n- second\n"},
+		{"Example:\n\tcode line\n\tcode line\n", "Example:\n\tcode line\n\tcode line\n"},
+	}
+
+	for _, test := range tests {
+		if got := joinParagraphs(test.text); got != test.want {
+			t.Fatalf("Joining paragraphs of %q mismatch: got %q, want %q", test.text, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package distiller
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// minFlags is the minimum number of power of two constants to detect a set of bit flags, so that
+// enumerations of few values, e.g. 0, 1 and 2, are not mistaken for flags.
+const minFlags = 3
+
+// IsFlagSet reports whether typed constants are bit flags that can be combined, i.e. whether their
+// values are distinct powers of two, zero or combinations of them, e.g. FlagAll = FlagA | FlagB | FlagC.
+// Unless all the powers of two are declared as left shifts, e.g. 1 << iota, the constants must be
+// of an unsigned integer type and no combination can be declared between them, so that sequences
+// as 0, 1, 2, 3 and 4 are enumerations.
+func IsFlagSet(consts []*ConstInfo) bool {
+	flags := 0
+	seen := uint64(0)
+	shifted := true
+	unsigned := true
+	interleaved := false
+	pendingCombined := false // A combination follows the last power of two.
+	var combined []uint64
+	for _, info := range consts {
+		// Constants encoded as strings, e.g. marshaled as text, are not flags.
+		value, err := strconv.ParseUint(info.JSON, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		switch bits.OnesCount64(value) {
+		case 0:
+
+		case 1:
+			if seen&value != 0 {
+				return false
+			}
+
+			interleaved = interleaved || pendingCombined
+			seen |= value
+			flags++
+			shifted = shifted && info.shifted
+			unsigned = unsigned && info.unsigned
+
+		default:
+			combined = append(combined, value)
+			pendingCombined = seen != 0
+		}
+	}
+
+	for _, value := range combined {
+		if value&^seen != 0 {
+			return false
+		}
+	}
+
+	return flags >= minFlags && (shifted || unsigned && !interleaved)
+}
+
+// DecomposeFlags returns the name of the flag constant having value or, if none, the names of the
+// flags combined in value separated by |, e.g. "FlagA | FlagB". Bits not matching any flag are
+// appended as number. It returns an empty string if value is not an unsigned integer or matches
+// no flags.
+func DecomposeFlags(consts []*ConstInfo, value string) string {
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	for _, info := range consts {
+		if info.Value == value {
+			return info.Name
+		}
+	}
+
+	var names []string
+	for _, info := range consts {
+		flag, _ := strconv.ParseUint(info.Value, 10, 64)
+		if bits.OnesCount64(flag) == 1 && v&flag == flag {
+			names = append(names, info.Name)
+			v &^= flag
+		}
+	}
+
+	if names == nil {
+		return ""
+	}
+
+	if v != 0 {
+		names = append(names, strconv.FormatUint(v, 10))
+	}
+
+	return strings.Join(names, " | ")
+}
@@ -0,0 +1,101 @@
+package distiller
+
+import (
+	"slices"
+	"testing"
+)
+
+// newConsts creates constants of an unsigned type with given names and values, encoded as numbers.
+func newConsts(namesValues ...string) []*ConstInfo {
+	var consts []*ConstInfo
+	for i := 0; i < len(namesValues); i += 2 {
+		consts = append(consts, &ConstInfo{Name: namesValues[i], Value: namesValues[i+1], JSON: namesValues[i+1],
+			unsigned: true})
+	}
+
+	return consts
+}
+
+// shift marks the named constants as declared by left shift expressions.
+func shift(consts []*ConstInfo, names ...string) []*ConstInfo {
+	for _, info := range consts {
+		info.shifted = slices.Contains(names, info.Name)
+	}
+
+	return consts
+}
+
+// signed marks the constants as of a signed type.
+func signed(consts []*ConstInfo) []*ConstInfo {
+	for _, info := range consts {
+		info.unsigned = false
+	}
+
+	return consts
+}
+
+func TestIsFlagSet(t *testing.T) {
+	tests := []struct {
+		consts []*ConstInfo
+		want   bool
+	}{
+		{newConsts("A", "1", "B", "2", "C", "4"), true},
+		{newConsts("None", "0", "A", "1", "B", "2", "C", "4", "All", "7"), true},
+		{newConsts("A", "0", "B", "1", "C", "2"), false},
+		{newConsts("A", "1", "B", "2", "C", "3"), false},
+		{newConsts("A", "0", "B", "1", "C", "2", "D", "3", "E", "4"), false},
+		{newConsts("A", "1", "B", "2", "C", "3", "D", "4"), false},
+		{shift(newConsts("A", "1", "B", "2", "AB", "3", "C", "4"), "A", "B", "C"), true},
+		{shift(newConsts("A", "1", "B", "2", "AB", "3", "C", "4"), "A", "B"), false},
+		{signed(newConsts("A", "1", "B", "2", "C", "4")), false},
+		{signed(shift(newConsts("A", "1", "B", "2", "C", "4"), "A", "B", "C")), true},
+		{signed(shift(newConsts("A", "0", "B", "1", "C", "2", "D", "32", "E", "64", "F", "128"), "D", "E", "F")), false},
+		{newConsts("A", "1", "B", "2", "C", "4", "D", "4"), false},
+		{newConsts("A", "1", "B", "2", "C", "4", "X", "9"), false},
+		{newConsts("A", "-1", "B", "2", "C", "4", "D", "8"), false},
+		{[]*ConstInfo{{Name: "A", Value: "1", JSON: `"a"`}, {Name: "B", Value: "2", JSON: `"b"`},
+			{Name: "C", Value: "4", JSON: `"c"`}}, false},
+	}
+
+	for _, test := range tests {
+		if got := IsFlagSet(test.consts); got != test.want {
+			t.Fatalf("Flag set mismatch for %v: got %v, want %v", test.consts, got, test.want)
+		}
+	}
+}
+
+func TestIsFlagSetDeclared(t *testing.T) {
+	if _, err := NewPackageInfo("../testdata/enums", "Enums"); err != nil {
+		t.Fatal(err)
+	}
+
+	const path = "github.com/marco-sacchi/go2jsonc/testdata/enums."
+	want := map[string]bool{"Permission": true, "Stage": false, "Mode": false}
+	for typeName, flags := range want {
+		if got := IsFlagSet(LookupTypedConsts(path + typeName)); got != flags {
+			t.Fatalf("Flag set mismatch for type %s: got %v, want %v", typeName, got, flags)
+		}
+	}
+}
+
+func TestDecomposeFlags(t *testing.T) {
+	consts := newConsts("None", "0", "A", "1", "B", "2", "C", "4", "AB", "3")
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"0", "None"},
+		{"1", "A"},
+		{"3", "AB"},
+		{"5", "A | C"},
+		{"13", "A | C | 8"},
+		{"8", ""},
+		{`"a"`, ""},
+	}
+
+	for _, test := range tests {
+		if got := DecomposeFlags(consts, test.value); got != test.want {
+			t.Fatalf("Flags decomposition mismatch for %s: got %q, want %q", test.value, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package distiller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONCTag holds the options of the jsonc struct tag, a comma-separated list of options
+// controlling the rendering of the field, e.g.:
+//
+//	Port int `jsonc:"example=8080,section=Network,range=1..65535"`
+//	Password string `jsonc:"secret=DB_PASSWORD"`
+type JSONCTag struct {
+	Example string // JSON literal rendered when no default value is defined.
+	Hidden  bool   // The field is omitted.
+	Section string // Title of the section starting with the field.
+	Range   string // Range of allowed values as min..max, either bound can be omitted.
+
+	Secret    bool   // The value is secret, a placeholder is rendered.
+	SecretEnv string // Environment variable expected to set the secret value, optional.
+}
+
+// JSONCTag parses the jsonc tag of the field. It returns nil when the field has no jsonc tag and
+// an error for unknown options, options without the required value and invalid values.
+func (f *FieldInfo) JSONCTag() (*JSONCTag, error) {
+	tag, ok := f.Tags["jsonc"]
+	if !ok {
+		return nil, nil
+	}
+
+	// Quotes in tag values are escaped.
+	tag, err := strconv.Unquote(`"` + tag + `"`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonc tag: %w", err)
+	}
+
+	jsoncTag := new(JSONCTag)
+	for _, option := range splitTagOptions(tag) {
+		name, value, hasValue := strings.Cut(option, "=")
+		switch strings.TrimSpace(name) {
+		case "hidden":
+			if hasValue {
+				return nil, fmt.Errorf("jsonc tag option hidden takes no value")
+			}
+
+			jsoncTag.Hidden = true
+
+		case "example":
+			if !json.Valid([]byte(value)) {
+				return nil, fmt.Errorf("jsonc tag option example is not a valid JSON literal: %q", value)
+			}
+
+			jsoncTag.Example = value
+
+		case "section":
+			if value == "" {
+				return nil, fmt.Errorf("jsonc tag option section requires a value")
+			}
+
+			jsoncTag.Section = value
+
+		case "secret":
+			if hasValue && value == "" {
+				return nil, fmt.Errorf("jsonc tag option secret requires a value when = is present")
+			}
+
+			jsoncTag.Secret = true
+			jsoncTag.SecretEnv = value
+
+		case "range":
+			if err = validateRange(value); err != nil {
+				return nil, err
+			}
+
+			jsoncTag.Range = value
+
+		default:
+			return nil, fmt.Errorf("unknown jsonc tag option %q", name)
+		}
+	}
+
+	return jsoncTag, nil
+}
+
+// splitTagOptions splits the options of a tag on commas not enclosed in quotes, brackets or braces,
+// so that JSON literals can be used as values.
+func splitTagOptions(tag string) []string {
+	var options []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch c := tag[i]; {
+		case quoted && c == '\\':
+			i++
+
+		case c == '"':
+			quoted = !quoted
+
+		case quoted:
+
+		case c == '[' || c == '{':
+			depth++
+
+		case c == ']' || c == '}':
+			depth--
+
+		case c == ',' && depth == 0:
+			options = append(options, tag[start:i])
+			start = i + 1
+		}
+	}
+
+	if start < len(tag) {
+		options = append(options, tag[start:])
+	}
+
+	return options
+}
+
+// rangeNote returns the documentation note of a range of allowed values, either bound can be omitted.
+func rangeNote(minValue, maxValue string) string {
+	switch {
+	case maxValue == "":
+		return "Minimum: " + minValue
+
+	case minValue == "":
+		return "Maximum: " + maxValue
+	}
+
+	return "Range: " + minValue + ".." + maxValue
+}
+
+// validateRange checks that a range is in the min..max format, with numeric bounds and min not
+// greater than max.
+func validateRange(value string) error {
+	minValue, maxValue, ok := strings.Cut(value, "..")
+	if !ok || (minValue == "" && maxValue == "") {
+		return fmt.Errorf("jsonc tag option range must be in the min..max format: %s", value)
+	}
+
+	var bounds [2]float64
+	for i, bound := range []string{minValue, maxValue} {
+		if bound == "" {
+			continue
+		}
+
+		n, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return fmt.Errorf("jsonc tag option range has an invalid bound: %s", bound)
+		}
+
+		bounds[i] = n
+	}
+
+	if minValue != "" && maxValue != "" && bounds[0] > bounds[1] {
+		return fmt.Errorf("jsonc tag option range has min greater than max: %s", value)
+	}
+
+	return nil
+}
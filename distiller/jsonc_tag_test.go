@@ -0,0 +1,56 @@
+package distiller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldInfo_JSONCTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want *JSONCTag
+		err  bool
+	}{
+		{tag: "hidden", want: &JSONCTag{Hidden: true}},
+		{tag: "example=8080,section=Network,range=1..65535",
+			want: &JSONCTag{Example: "8080", Section: "Network", Range: "1..65535"}},
+		{tag: `example=[\"a\", \"b,c\"],range=-1.5..`, want: &JSONCTag{Example: `["a", "b,c"]`, Range: "-1.5.."}},
+		{tag: `example={\"a\": [1, 2]}`, want: &JSONCTag{Example: `{"a": [1, 2]}`}},
+		{tag: "secret", want: &JSONCTag{Secret: true}},
+		{tag: "secret=DB_PASSWORD", want: &JSONCTag{Secret: true, SecretEnv: "DB_PASSWORD"}},
+		{tag: "secret=", err: true},
+		{tag: "hidden=true", err: true},
+		{tag: "example=", err: true},
+		{tag: "example=info", err: true},
+		{tag: "section", err: true},
+		{tag: "range=1-10", err: true},
+		{tag: "range=..", err: true},
+		{tag: "range=a..b", err: true},
+		{tag: "range=10..1", err: true},
+		{tag: "default=1", err: true},
+	}
+
+	for _, test := range tests {
+		field := &FieldInfo{Tags: map[string]string{"jsonc": test.tag}}
+		got, err := field.JSONCTag()
+		if test.err {
+			if err == nil {
+				t.Fatalf("Parsing jsonc tag %q: expected error, got %+v", test.tag, got)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Parsing jsonc tag %q: %v", test.tag, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("Parsed jsonc tag %q mismatch: got %+v, want %+v", test.tag, got, test.want)
+		}
+	}
+
+	if tag, err := (&FieldInfo{}).JSONCTag(); tag != nil || err != nil {
+		t.Fatalf("Parsing missing jsonc tag: got %+v, %v, want nil", tag, err)
+	}
+}
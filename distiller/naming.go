@@ -0,0 +1,92 @@
+package distiller
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy defines the conversions of field names to the keys of fields without a name in
+// the json tag.
+type NamingStrategy int
+
+const (
+	AsIsNames           NamingStrategy = iota // Field names as declared, as encoding/json does (default).
+	LowerCamelNames                           // Field names in lower camel case, e.g. maxRetries.
+	SnakeCaseNames                            // Field names in snake case, e.g. max_retries.
+	KebabCaseNames                            // Field names in kebab case, e.g. max-retries.
+	ScreamingSnakeNames                       // Field names in upper snake case, e.g. MAX_RETRIES.
+)
+
+// Apply converts the field name to a key following the naming strategy. Words are split at case
+// changes, keeping acronyms together, e.g. APIKey is converted to api_key in snake case.
+func (n NamingStrategy) Apply(name string) string {
+	words := splitWords(name)
+	if n == AsIsNames || len(words) == 0 {
+		return name
+	}
+
+	switch n {
+	case LowerCamelNames:
+		words[0] = strings.ToLower(words[0])
+		return strings.Join(words, "")
+
+	case KebabCaseNames:
+		return strings.ToLower(strings.Join(words, "-"))
+
+	case ScreamingSnakeNames:
+		return strings.ToUpper(strings.Join(words, "_"))
+	}
+
+	return strings.ToLower(strings.Join(words, "_"))
+}
+
+// splitWords splits a Go identifier in words, at underscores, before an upper case letter following
+// a lower case letter or a digit, and before the last letter of a sequence of upper case ones
+// followed by a lower case letter, unless it is a version suffix as in IPv4 or a plural as in URLs.
+func splitWords(name string) []string {
+	var words []string
+	for _, part := range strings.Split(name, "_") {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if !unicode.IsUpper(runes[i]) {
+				continue
+			}
+
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+					!isAcronymSuffix(runes[i+1:])) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+
+		if start < len(runes) {
+			words = append(words, string(runes[start:]))
+		}
+	}
+
+	return words
+}
+
+// isAcronymSuffix reports whether the runes following an acronym, starting with a lower case letter,
+// are part of it: a version, as v4 in IPv4, or a plural s not followed by lower case letters, as
+// in URLs or UserIDsByName.
+func isAcronymSuffix(runes []rune) bool {
+	if len(runes) > 1 && unicode.IsDigit(runes[1]) {
+		return true
+	}
+
+	return runes[0] == 's' && (len(runes) == 1 || !unicode.IsLower(runes[1]))
+}
+
+// KeyName returns the key of the field: the name in the json tag or, when missing, the field name
+// converted by the naming strategy.
+func (f *FieldInfo) KeyName(naming NamingStrategy) string {
+	if jsonName, _, _ := strings.Cut(f.Tags["json"], ","); jsonName != "" {
+		return jsonName
+	}
+
+	return naming.Apply(f.Name)
+}
@@ -0,0 +1,51 @@
+package distiller
+
+import (
+	"testing"
+)
+
+func TestNamingStrategy_Apply(t *testing.T) {
+	want := map[string][5]string{
+		"Name":        {"Name", "name", "name", "name", "NAME"},
+		"MaxRetries":  {"MaxRetries", "maxRetries", "max_retries", "max-retries", "MAX_RETRIES"},
+		"APIKey":      {"APIKey", "apiKey", "api_key", "api-key", "API_KEY"},
+		"UserID":      {"UserID", "userID", "user_id", "user-id", "USER_ID"},
+		"IPv4Only":    {"IPv4Only", "ipv4Only", "ipv4_only", "ipv4-only", "IPV4_ONLY"},
+		"Port2Range":  {"Port2Range", "port2Range", "port2_range", "port2-range", "PORT2_RANGE"},
+		"Retry_Count": {"Retry_Count", "retryCount", "retry_count", "retry-count", "RETRY_COUNT"},
+		"UserIDs":     {"UserIDs", "userIDs", "user_ids", "user-ids", "USER_IDS"},
+		"URLs":        {"URLs", "urls", "urls", "urls", "URLS"},
+		"AllowedIPs":  {"AllowedIPs", "allowedIPs", "allowed_ips", "allowed-ips", "ALLOWED_IPS"},
+		"IDsByName":   {"IDsByName", "idsByName", "ids_by_name", "ids-by-name", "IDS_BY_NAME"},
+		"HTTPServer":  {"HTTPServer", "httpServer", "http_server", "http-server", "HTTP_SERVER"},
+		"":            {"", "", "", "", ""},
+	}
+
+	strategies := []NamingStrategy{AsIsNames, LowerCamelNames, SnakeCaseNames, KebabCaseNames, ScreamingSnakeNames}
+	for name, w := range want {
+		for i, naming := range strategies {
+			if got := naming.Apply(name); got != w[i] {
+				t.Fatalf("Key mismatch for name %q with strategy %d: got %q, want %q", name, naming, got, w[i])
+			}
+		}
+	}
+}
+
+func TestFieldInfo_KeyName(t *testing.T) {
+	tests := []struct {
+		field *FieldInfo
+		want  string
+	}{
+		{&FieldInfo{Name: "MaxRetries"}, "max_retries"},
+		{&FieldInfo{Name: "MaxRetries", Tags: map[string]string{"json": "retries"}}, "retries"},
+		{&FieldInfo{Name: "MaxRetries", Tags: map[string]string{"json": ",omitempty"}}, "max_retries"},
+		{&FieldInfo{Name: "MaxRetries", Tags: map[string]string{"yaml": "retries"}}, "max_retries"},
+	}
+
+	for _, test := range tests {
+		if got := test.field.KeyName(SnakeCaseNames); got != test.want {
+			t.Fatalf("Key name mismatch for field %s with tags %v: got %q, want %q",
+				test.field.Name, test.field.Tags, got, test.want)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"golang.org/x/tools/go/packages"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // PackageInfo holds information about a package.
@@ -22,10 +24,47 @@ type PackageInfo struct {
 // loadedPackages caches the loaded/imported packages.
 var loadedPackages = make(map[string]*PackageInfo)
 
+// scannedPackages caches the packages of the import graphs of the loaded ones, read only for their
+// typed constants.
+var scannedPackages = make(map[string]*PackageInfo)
+
+// sortedPackages returns the packages sorted by path, to make lookups independent of the map
+// iteration order.
+func sortedPackages(pkgs map[string]*PackageInfo) []*PackageInfo {
+	paths := make([]string, 0, len(pkgs))
+	for path := range pkgs {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	sorted := make([]*PackageInfo, len(paths))
+	for i, path := range paths {
+		sorted[i] = pkgs[path]
+	}
+
+	return sorted
+}
+
+// constPackages returns the packages declaring typed constants: the loaded ones and, if not loaded,
+// the ones only scanned.
+func constPackages() map[string]*PackageInfo {
+	pkgs := make(map[string]*PackageInfo, len(scannedPackages))
+	for path, pkg := range scannedPackages {
+		pkgs[path] = pkg
+	}
+
+	for path, pkg := range loadedPackages {
+		pkgs[path] = pkg
+	}
+
+	return pkgs
+}
+
 // LookupStruct searches loaded packages for the specified fully qualified struct name.
 // It returns nil in case of no matches.
 func LookupStruct(name string) *StructInfo {
-	for _, pkg := range loadedPackages {
+	for _, pkg := range sortedPackages(loadedPackages) {
 		s, ok := pkg.Structs[name]
 		if ok {
 			return s
@@ -35,20 +74,113 @@ func LookupStruct(name string) *StructInfo {
 	return nil
 }
 
-// LookupTypedConsts searches loaded packages for declared constants of specified fully qualified named type.
-// It returns nil in case of no matches.
+// LookupStructType searches loaded packages for the struct of specified named type, or the information
+// of an anonymous struct type declared inline. If the type is an instantiation of a generic struct,
+// the returned struct has the type arguments substituted in fields types. It returns nil in case of
+// no matches.
+func LookupStructType(t types.Type) *StructInfo {
+	t = types.Unalias(t)
+	if structType, ok := t.(*types.Struct); ok {
+		return inlineStructs[structType]
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	s := LookupStruct(named.Obj().Pkg().Path() + "." + named.Obj().Name())
+	if s == nil || named.TypeArgs().Len() == 0 {
+		return s
+	}
+
+	return s.instantiate(named)
+}
+
+// LookupTypedConsts searches loaded packages and their import graphs for declared constants of specified
+// fully qualified named type, also declared in packages other than the one of the type. Constants are
+// sorted by source position, those declared in the package of the type first. It returns nil in case
+// of no matches.
 func LookupTypedConsts(name string) []*ConstInfo {
 	consts := []*ConstInfo(nil)
-	for _, pkg := range loadedPackages {
+	pkgs := constPackages()
+	for _, pkg := range sortedPackages(pkgs) {
 		c, ok := pkg.TypedConsts[name]
 		if ok {
 			consts = append(consts, c...)
 		}
 	}
 
+	if consts == nil {
+		return nil
+	}
+
+	typePkgPath := ""
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		typePkgPath = name[:dot]
+	}
+
+	sort.SliceStable(consts, func(i, j int) bool {
+		a, b := consts[i], consts[j]
+		if (a.pkgPath == typePkgPath) != (b.pkgPath == typePkgPath) {
+			return a.pkgPath == typePkgPath
+		}
+
+		if a.pkgPath != b.pkgPath {
+			return a.pkgPath < b.pkgPath
+		}
+
+		if a.pos.Filename != b.pos.Filename {
+			return a.pos.Filename < b.pos.Filename
+		}
+
+		return a.pos.Offset < b.pos.Offset
+	})
+
+	for _, info := range consts {
+		if info.pending != nil {
+			info.resolveText(pkgs[typePkgPath])
+		}
+	}
+
 	return consts
 }
 
+// LookupImplementations searches loaded packages for the structs implementing the specified interface,
+// directly or through a pointer receiver. The returned structs are sorted by fully qualified name.
+// It returns nil for the empty interface and in case of no matches.
+func LookupImplementations(iface *types.Interface) []*StructInfo {
+	if iface.Empty() {
+		return nil
+	}
+
+	var names []string
+	structs := make(map[string]*StructInfo)
+	for _, pkg := range sortedPackages(loadedPackages) {
+		for name, s := range pkg.Structs {
+			t := s.Type()
+			// Generic structs cannot implement interfaces until instantiated.
+			if t.(*types.Named).TypeParams().Len() > 0 {
+				continue
+			}
+
+			if types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface) {
+				names = append(names, name)
+				structs[name] = s
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	var implementations []*StructInfo
+	for _, name := range names {
+		implementations = append(implementations, structs[name])
+	}
+
+	return implementations
+}
+
 // NewPackageInfo creates a new package information object from given directory. The passed name defines
 // the struct for which read also defaults values.
 func NewPackageInfo(dir string, typeName string) (*PackageInfo, error) {
@@ -61,9 +193,12 @@ func NewPackageInfo(dir string, typeName string) (*PackageInfo, error) {
 		return nil, err
 	}
 
+	loadedPackages[pkgInfo.Package.PkgPath] = pkgInfo
+
 	if typeName != "" {
-		s, ok := pkgInfo.Structs[pkgInfo.Package.PkgPath+"."+typeName]
-		if !ok {
+		s := pkgInfo.LookupStruct(typeName)
+		if s == nil {
+			delete(loadedPackages, pkgInfo.Package.PkgPath)
 			return nil, fmt.Errorf("cannot find struct %s in package %s", typeName, pkgInfo.Package.PkgPath)
 		}
 
@@ -72,11 +207,25 @@ func NewPackageInfo(dir string, typeName string) (*PackageInfo, error) {
 		}
 	}
 
-	loadedPackages[pkgInfo.Package.PkgPath] = pkgInfo
-
 	return pkgInfo, nil
 }
 
+// LookupStruct searches the package for the struct declared with the specified unqualified name,
+// resolving type aliases to the target struct, also declared in another package. It returns nil
+// in case of no matches.
+func (p *PackageInfo) LookupStruct(name string) *StructInfo {
+	if s, ok := p.Structs[p.Package.PkgPath+"."+name]; ok {
+		return s
+	}
+
+	typeName, ok := p.Package.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok || !typeName.IsAlias() {
+		return nil
+	}
+
+	return LookupStructType(typeName.Type())
+}
+
 // readPackage reads information for the package defined in the given directory and all imported packages.
 func (p *PackageInfo) readPackage(dir string) error {
 	ok, err := isDirectory(dir)
@@ -104,12 +253,11 @@ func (p *PackageInfo) readPackage(dir string) error {
 	}
 
 	p.Package = pkgs[0]
+	p.readConsts()
+	p.scanImports()
 
-	for ident, object := range p.Package.TypesInfo.Defs {
-		typeName, ok := object.(*types.TypeName)
-		if !ok {
-			continue
-		}
+	for _, ident := range p.typeIdents() {
+		typeName := p.Package.TypesInfo.Defs[ident].(*types.TypeName)
 
 		for _, astFile := range p.Package.Syntax {
 			// Use the position to test if the type is declared in this file.
@@ -117,10 +265,10 @@ func (p *PackageInfo) readPackage(dir string) error {
 				continue
 			}
 
-			typeNameString := typeName.Type().String()
+			// Generic types are keyed without type parameters.
+			typeNameString := typeName.Pkg().Path() + "." + typeName.Name()
 
 			nodes, _ := astutil.PathEnclosingInterval(astFile, typeName.Pos(), typeName.Pos())
-			isStruct := false
 			for _, node := range nodes {
 				var genDecl *ast.GenDecl
 				genDecl, ok = node.(*ast.GenDecl)
@@ -140,81 +288,227 @@ func (p *PackageInfo) readPackage(dir string) error {
 					continue
 				}
 
-				// The identifier matches but the type is not a struct, exit loop.
-				if _, ok = typeSpec.Type.(*ast.StructType); !ok {
+				// Aliases are resolved to their target type, loading the package where it is declared.
+				if typeName.IsAlias() {
+					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+						inline := newInlineStructInfo(structType, p.Package)
+						inlineStructs[inline.inlineType] = inline
+					}
+
+					if err = p.loadReferencedPackages(typeName.Type()); err != nil {
+						return err
+					}
+
 					break
 				}
 
-				info := NewStructInfo(genDecl, p.Package)
-				for _, field := range info.Fields {
-					var namedType *types.Named
-					namedType, ok = field.Type.(*types.Named)
-
-					// types.Basic type.
-					if !ok {
-						continue
+				// The identifier matches but the type is not a struct, exit loop loading the
+				// packages of elements of named slices, arrays and maps.
+				if _, ok = typeSpec.Type.(*ast.StructType); !ok {
+					if err = p.loadReferencedPackages(typeName.Type().Underlying()); err != nil {
+						return err
 					}
 
-					// Check if required package is loaded.
-					pkgPath := namedType.Obj().Pkg().Path()
-					_, ok = loadedPackages[pkgPath]
-					if pkgPath == p.Package.PkgPath || ok {
-						continue
-					}
+					break
+				}
 
-					// Load required package.
-					imported := p.Package.Imports[pkgPath]
-					if _, err = NewPackageInfo(filepath.Dir(imported.GoFiles[0]), ""); err != nil {
+				info := NewStructInfo(genDecl, p.Package)
+				for _, field := range info.Fields {
+					if err = p.loadReferencedPackages(field.Type); err != nil {
 						return err
 					}
 				}
 
 				p.Structs[typeNameString] = info
-				isStruct = true
 				break
 			}
-
-			// Not a struct, check if identifier is used by typed constants.
-			if !isStruct {
-				if consts := p.readIdentConsts(astFile, ident); consts != nil {
-					p.TypedConsts[typeNameString] = consts
-				}
-			}
 		}
 	}
 
 	return nil
 }
 
-// readIdentConsts reads typed constants what uses ident type. Returns nil if no constants use the type.
-func (p *PackageInfo) readIdentConsts(astFile *ast.File, ident *ast.Ident) []*ConstInfo {
-	consts := []*ConstInfo(nil)
-	for _, decl := range astFile.Decls {
-		genDecl, ok := decl.(*ast.GenDecl)
-		if !ok || genDecl.Tok != token.CONST {
+// typeIdents returns the identifiers defining type names in the package, sorted by source position,
+// so that types are read, and referenced packages loaded, in a deterministic order.
+func (p *PackageInfo) typeIdents() []*ast.Ident {
+	var idents []*ast.Ident
+	for ident, object := range p.Package.TypesInfo.Defs {
+		if _, ok := object.(*types.TypeName); ok {
+			idents = append(idents, ident)
+		}
+	}
+
+	sort.Slice(idents, func(i, j int) bool {
+		return idents[i].Pos() < idents[j].Pos()
+	})
+
+	return idents
+}
+
+// loadReferencedPackages loads the packages declaring the types referenced by t, if not already loaded.
+func (p *PackageInfo) loadReferencedPackages(t types.Type) error {
+	for _, typeName := range referencedTypeNames(t) {
+		// Predeclared types, e.g. error, have no package, while well-known
+		// and marshaler types are not rendered structurally.
+		// Enums marshaled as text are loaded for their constants.
+		_, isBasic := typeName.Type().Underlying().(*types.Basic)
+		if typeName.Pkg() == nil || IsWellKnownType(typeName.Type()) ||
+			(MarshalerMethod(typeName.Type()) != "" && !isBasic) {
 			continue
 		}
 
-		for _, spec := range genDecl.Specs {
-			var valueSpec *ast.ValueSpec
-			valueSpec, ok = spec.(*ast.ValueSpec)
-			if !ok {
+		// Check if required package is loaded.
+		pkgPath := typeName.Pkg().Path()
+		if _, ok := loadedPackages[pkgPath]; pkgPath == p.Package.PkgPath || ok {
+			continue
+		}
+
+		// Load required package.
+		imported := p.Package.Imports[pkgPath]
+		if imported == nil {
+			return fmt.Errorf("package %s of type %s is not imported by %s",
+				pkgPath, typeName.Name(), p.Package.PkgPath)
+		}
+
+		if _, err := NewPackageInfo(filepath.Dir(imported.GoFiles[0]), ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readConsts reads the typed constants declared in all the files of the package, grouping them by
+// the fully qualified name of their type, also when declared in another package. Texts of constants
+// of types declared in this package and marshaled as text are resolved.
+func (p *PackageInfo) readConsts() {
+	for _, astFile := range p.Package.Syntax {
+		for _, decl := range astFile.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
 				continue
 			}
 
-			if valueSpec.Type != nil {
-				var constIdent *ast.Ident
-				constIdent, ok = valueSpec.Type.(*ast.Ident)
-				if !ok || constIdent.Obj != ident.Obj {
-					continue
+			// Specs without values repeat the expressions of the previous one.
+			var values []ast.Expr
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				if valueSpec.Values != nil {
+					values = valueSpec.Values
+				}
+
+				for i, name := range valueSpec.Names {
+					// Match by type, so that implicitly typed specs of iota sequences are included
+					// and untyped constants are not. Constants typed by an alias of the type match too.
+					object, ok := p.Package.TypesInfo.Defs[name].(*types.Const)
+					if !ok || name.Name == "_" {
+						continue
+					}
+
+					named, ok := types.Unalias(object.Type()).(*types.Named)
+					if !ok || named.Obj().Pkg() == nil {
+						continue
+					}
+
+					info := newConstInfo(name, valueSpec, p.Package)
+					info.shifted = i < len(values) && isShift(values[i])
+					if MarshalerMethod(named) == "MarshalText" {
+						info.pending = object
+						if named.Obj().Pkg().Path() == p.Package.PkgPath {
+							info.resolveText(p)
+						}
+					}
+
+					typeNameString := named.Obj().Pkg().Path() + "." + named.Obj().Name()
+					p.TypedConsts[typeNameString] = append(p.TypedConsts[typeNameString], info)
 				}
 			}
+		}
+	}
+}
 
-			consts = append(consts, NewConstInfo(valueSpec, p.Package))
+// scanImports reads the typed constants of the packages imported by this one, directly or not, as
+// they can declare constants of the types of the loaded packages.
+func (p *PackageInfo) scanImports() {
+	packages.Visit([]*packages.Package{p.Package}, func(pkg *packages.Package) bool {
+		if pkg == p.Package {
+			return true
 		}
+
+		// Imports of scanned packages are scanned too.
+		if _, ok := scannedPackages[pkg.PkgPath]; ok || pkg.TypesInfo == nil {
+			return false
+		}
+
+		scanned := &PackageInfo{
+			Package:     pkg,
+			Structs:     make(map[string]*StructInfo),
+			TypedConsts: make(map[string][]*ConstInfo),
+		}
+		scanned.readConsts()
+		scannedPackages[pkg.PkgPath] = scanned
+
+		return true
+	}, nil)
+}
+
+// isShift reports whether expr is a left shift expression, also enclosed in parentheses or converted
+// to a type, e.g. Flag(1 << iota).
+func isShift(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		return e.Op == token.SHL
+
+	case *ast.ParenExpr:
+		return isShift(e.X)
+
+	case *ast.CallExpr:
+		return len(e.Args) == 1 && isShift(e.Args[0])
 	}
 
-	return consts
+	return false
+}
+
+// referencedTypeNames returns the type names of the named types and aliases referenced by t, looking
+// through pointers, arrays, slices and maps. Alias targets are not included, the package declaring
+// the alias references them.
+func referencedTypeNames(t types.Type) []*types.TypeName {
+	switch typ := t.(type) {
+	case *types.Alias:
+		return []*types.TypeName{typ.Obj()}
+
+	case *types.Named:
+		// Type arguments of instantiated generic types are referenced too.
+		names := []*types.TypeName{typ.Obj()}
+		for i := 0; i < typ.TypeArgs().Len(); i++ {
+			names = append(names, referencedTypeNames(typ.TypeArgs().At(i))...)
+		}
+
+		return names
+
+	case *types.Pointer:
+		return referencedTypeNames(typ.Elem())
+
+	case *types.Array:
+		return referencedTypeNames(typ.Elem())
+
+	case *types.Slice:
+		return referencedTypeNames(typ.Elem())
+
+	case *types.Map:
+		return append(referencedTypeNames(typ.Key()), referencedTypeNames(typ.Elem())...)
+
+	case *types.Struct:
+		// Fields of inline structs.
+		var names []*types.TypeName
+		for i := 0; i < typ.NumFields(); i++ {
+			names = append(names, referencedTypeNames(typ.Field(i).Type())...)
+		}
+
+		return names
+	}
+
+	return nil
 }
 
 // isDirectory reports whether the named file is a directory.
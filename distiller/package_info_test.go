@@ -1,6 +1,9 @@
 package distiller
 
-import "testing"
+import (
+	"go/types"
+	"testing"
+)
 
 func TestPackageInfo(t *testing.T) {
 	info, err := NewPackageInfo("../testdata", "")
@@ -101,3 +104,150 @@ func TestPackageInfoMultiPackage(t *testing.T) {
 		t.Fatalf("Lookup of invalid struct, error expected, got nil")
 	}
 }
+
+func TestLookupImplementations(t *testing.T) {
+	info, err := NewPackageInfo("../testdata/plugins", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := info.Package.Types.Scope().Lookup("Sink").Type().Underlying().(*types.Interface)
+	implementations := LookupImplementations(sink)
+
+	want := []string{"FileSink", "HTTPSink"}
+	if len(implementations) != len(want) {
+		t.Fatalf("Found %d implementations, want %d", len(implementations), len(want))
+	}
+
+	for i, implementation := range implementations {
+		if implementation.Name != want[i] {
+			t.Fatalf("Implementation mismatch: got %s, want %s", implementation.Name, want[i])
+		}
+	}
+
+	if LookupImplementations(types.NewInterfaceType(nil, nil)) != nil {
+		t.Fatalf("Lookup of empty interface implementations not nil")
+	}
+}
+
+func TestLookupStructType(t *testing.T) {
+	info, err := NewPackageInfo("../testdata/generics", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generics := info.Structs[info.Package.PkgPath+".Generics"]
+
+	want := map[string][]string{
+		"Conns": {
+			"[]github.com/marco-sacchi/go2jsonc/testdata/generics.Conn",
+			"int",
+			"github.com/marco-sacchi/go2jsonc/testdata/generics.Conn",
+		},
+		"Retries": {"bool", "int"},
+		"Labels":  {"map[string][]int"},
+		"Status":  {"bool", "github.com/marco-sacchi/go2jsonc/testdata/multipkg/network.Status"},
+	}
+
+	for _, field := range generics.Fields {
+		wantTypes, ok := want[field.Name]
+		if !ok {
+			continue
+		}
+
+		s := LookupStructType(field.Type)
+		if s == nil {
+			t.Fatalf("Cannot lookup struct of type %s", field.Type)
+		}
+
+		if len(s.Fields) != len(wantTypes) {
+			t.Fatalf("Instantiated struct %s has %d fields, want %d", field.Type, len(s.Fields), len(wantTypes))
+		}
+
+		for i, f := range s.Fields {
+			if f.Type.String() != wantTypes[i] {
+				t.Fatalf("Field %s of %s type mismatch: got %s, want %s", f.Name, field.Type, f.Type, wantTypes[i])
+			}
+		}
+	}
+
+	// The generic struct is not modified by instantiations.
+	pool := LookupStruct(info.Package.PkgPath + ".Pool")
+	if pool == nil || pool.Fields[0].Type.String() != "[]T" {
+		t.Fatalf("Generic struct Pool modified or not found: %v", pool)
+	}
+
+	if LookupStructType(types.Typ[types.Int]) != nil {
+		t.Fatalf("Lookup of basic type struct not nil")
+	}
+}
+
+func TestPackageInfoNamedTypes(t *testing.T) {
+	info, err := NewPackageInfo("../testdata/named", "Alias")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	named := info.LookupStruct("Named")
+	if named == nil || info.LookupStruct("Alias") != named {
+		t.Fatalf("Alias does not resolve to the Named struct")
+	}
+
+	want := map[string]FieldLayout{
+		"Hosts":    LayoutArray,
+		"Labels":   LayoutMap,
+		"Backends": LayoutArray,
+		"Uplink":   LayoutSingle,
+		"State":    LayoutSingle,
+		"Ports":    LayoutArray,
+		"Groups":   LayoutArray,
+	}
+
+	for _, field := range named.Fields {
+		if field.Layout != want[field.Name] {
+			t.Fatalf("Field %s layout mismatch: got %v, want %v", field.Name, field.Layout, want[field.Name])
+		}
+	}
+
+	// Structs and constants of packages referenced through aliases and named slices are loaded.
+	if LookupStructType(named.Fields[3].Type) == nil {
+		t.Fatalf("Cannot lookup struct of aliased type %s", named.Fields[3].Type)
+	}
+
+	if LookupTypedConsts(types.Unalias(named.Fields[4].Type).String()) == nil {
+		t.Fatalf("Cannot lookup constants of aliased type %s", named.Fields[4].Type)
+	}
+}
+
+func TestLookupTypedConstsAcrossPackages(t *testing.T) {
+	if _, err := NewPackageInfo("../testdata/enums", "Enums"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		typeName string
+		want     []string
+	}{
+		{
+			"github.com/marco-sacchi/go2jsonc/testdata/enums.Mode",
+			[]string{"ModeActive", "ModeStandby", "ModeMaintenance"},
+		},
+		{
+			"github.com/marco-sacchi/go2jsonc/testdata/enums/levels.Priority",
+			[]string{"PriorityLow", "PriorityNormal", "PriorityHigh", "PriorityCritical", "PriorityIdle"},
+		},
+	}
+
+	for _, test := range tests {
+		consts := LookupTypedConsts(test.typeName)
+		if len(consts) != len(test.want) {
+			t.Fatalf("Constants count mismatch for %s: got %d, want %d", test.typeName, len(consts), len(test.want))
+		}
+
+		for i, c := range consts {
+			if c.Name != test.want[i] {
+				t.Fatalf("Constant %d of %s mismatch: got %s, want %s", i, test.typeName, c.Name, test.want[i])
+			}
+		}
+	}
+}
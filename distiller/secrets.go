@@ -0,0 +1,31 @@
+package distiller
+
+import (
+	"go/types"
+	"regexp"
+)
+
+// secretNameRegexp matches the names of fields commonly used for secrets, e.g. Password, DBPassword,
+// AuthToken or APIKey.
+var secretNameRegexp = regexp.MustCompile(`(?:Password|Passwd|Secret|Token|APIKey|ApiKey|PrivateKey|AccessKey)$`)
+
+// Secret reports whether the field is marked as secret by the secret directive or option of the
+// jsonc tag or, when heuristics is set, has a string type and a name commonly used for secrets.
+// It also returns the name of the environment variable expected to set the value, when specified
+// as value of the directive or option.
+func (f *FieldInfo) Secret(heuristics bool) (env string, secret bool) {
+	if env, ok := f.Directive(DirectiveSecret); ok {
+		return env, true
+	}
+
+	if jsoncTag, _ := f.JSONCTag(); jsoncTag != nil && jsoncTag.Secret {
+		return jsoncTag.SecretEnv, true
+	}
+
+	if !heuristics || f.IsEmbedded {
+		return "", false
+	}
+
+	basic, ok := DerefType(f.Type).Underlying().(*types.Basic)
+	return "", ok && basic.Info()&types.IsString != 0 && secretNameRegexp.MatchString(f.Name)
+}
@@ -0,0 +1,39 @@
+package distiller
+
+import (
+	"go/types"
+	"testing"
+)
+
+func TestFieldInfo_Secret(t *testing.T) {
+	stringType := types.Typ[types.String]
+
+	tests := []struct {
+		field      *FieldInfo
+		heuristics bool
+		env        string
+		secret     bool
+	}{
+		{&FieldInfo{Name: "Password", Type: stringType}, true, "", true},
+		{&FieldInfo{Name: "DBPassword", Type: types.NewPointer(stringType)}, true, "", true},
+		{&FieldInfo{Name: "APIKey", Type: stringType}, true, "", true},
+		{&FieldInfo{Name: "ClientSecret", Type: stringType}, true, "", true},
+		{&FieldInfo{Name: "Password", Type: stringType}, false, "", false},
+		{&FieldInfo{Name: "TokenLimit", Type: stringType}, true, "", false},
+		{&FieldInfo{Name: "AuthToken", Type: types.Typ[types.Int]}, true, "", false},
+		{&FieldInfo{Name: "Key", Type: stringType, Directives: map[string]string{DirectiveSecret: "KEY"}},
+			false, "KEY", true},
+		{&FieldInfo{Name: "Key", Type: stringType, Tags: map[string]string{"jsonc": "secret=KEY"}},
+			false, "KEY", true},
+		{&FieldInfo{Name: "Hooks", Type: types.NewSlice(stringType), Tags: map[string]string{"jsonc": "secret"}},
+			false, "", true},
+	}
+
+	for _, test := range tests {
+		env, secret := test.field.Secret(test.heuristics)
+		if env != test.env || secret != test.secret {
+			t.Fatalf("Field %s secret mismatch: got %q, %v, want %q, %v",
+				test.field.Name, env, secret, test.env, test.secret)
+		}
+	}
+}
@@ -1,11 +1,17 @@
 package distiller
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/marco-sacchi/go2jsonc/ordered"
 	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
 	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
 	"strings"
+	"time"
 )
 
 // StructInfo holds information about a struct.
@@ -15,6 +21,10 @@ type StructInfo struct {
 	Doc     string            // Documentation content if present.
 	Fields  []*FieldInfo      // Struct fields.
 
+	// Directives in documentation as map of name-value pairs, nil if none. They apply to all
+	// fields of the struct type, unless overridden by the directives of the field.
+	Directives map[string]string
+
 	// If a function with following signature:
 	//
 	//     func <StructName>Defaults() *StructName
@@ -22,6 +32,15 @@ type StructInfo struct {
 	// is found on the same package where the struct is declared
 	// it will be parsed to extract default values for all fields.
 	Defaults map[string]interface{} // Map of defaults values for struct fields.
+
+	inlineType *types.Struct // Type of anonymous struct declared inline, nil for named structs.
+}
+
+// ConcreteValue holds the default value of an interface field assigned a composite literal, with
+// the dynamic type of the value, e.g. *FileSink for &FileSink{...}.
+type ConcreteValue struct {
+	Type  types.Type  // Dynamic type of the value.
+	Value interface{} // Value parsed as for fields of the dynamic type.
 }
 
 func (s *StructInfo) String() string {
@@ -36,22 +55,76 @@ func (s *StructInfo) String() string {
 // and types info read on package loading.
 func NewStructInfo(genDecl *ast.GenDecl, pkg *packages.Package) *StructInfo {
 	typeSpec := genDecl.Specs[0].(*ast.TypeSpec)
-	structType := typeSpec.Type.(*ast.StructType)
 
 	info := &StructInfo{
 		Package: pkg,
 		Name:    typeSpec.Name.Name,
-		Doc:     genDecl.Doc.Text() + typeSpec.Doc.Text() + typeSpec.Comment.Text(),
+		Doc:     stripDirectives(genDecl.Doc.Text() + typeSpec.Doc.Text() + typeSpec.Comment.Text()),
+
+		Directives: parseDirectives(genDecl.Doc, typeSpec.Doc, typeSpec.Comment),
 	}
 
-	for _, field := range structType.Fields.List {
-		f := NewFieldInfo(field, info.Package)
-		info.Fields = append(info.Fields, f)
+	info.readFields(typeSpec.Type.(*ast.StructType))
+	return info
+}
+
+// newInlineStructInfo creates a new struct information object for an anonymous struct type
+// declared inline, e.g. as type of a field.
+func newInlineStructInfo(structType *ast.StructType, pkg *packages.Package) *StructInfo {
+	info := &StructInfo{
+		Package:    pkg,
+		inlineType: pkg.TypesInfo.Types[structType].Type.(*types.Struct),
 	}
 
+	info.readFields(structType)
 	return info
 }
 
+// readFields reads the fields information of given struct type.
+func (s *StructInfo) readFields(structType *ast.StructType) {
+	for _, field := range structType.Fields.List {
+		if len(field.Names) > 1 {
+			// Fields declared together share type, tags and documentation.
+			for _, name := range field.Names {
+				single := *field
+				single.Names = []*ast.Ident{name}
+				s.Fields = append(s.Fields, NewFieldInfo(&single, s.Package))
+			}
+
+			continue
+		}
+
+		f := NewFieldInfo(field, s.Package)
+		s.Fields = append(s.Fields, f)
+	}
+}
+
+// Type returns the type of the struct, named unless the struct is declared inline.
+func (s *StructInfo) Type() types.Type {
+	if s.inlineType != nil {
+		return s.inlineType
+	}
+
+	return s.Package.Types.Scope().Lookup(s.Name).Type()
+}
+
+// instantiate returns a copy of the generic struct with the type arguments of the given instantiated
+// type substituted in fields types.
+func (s *StructInfo) instantiate(named *types.Named) *StructInfo {
+	structType := named.Underlying().(*types.Struct)
+
+	instance := *s
+	instance.Fields = make([]*FieldInfo, len(s.Fields))
+	for i, field := range s.Fields {
+		f := *field
+		f.Type = structType.Field(i).Type()
+		f.Layout, f.KeyType, f.EltType = layoutOf(f.Type)
+		instance.Fields[i] = &f
+	}
+
+	return &instance
+}
+
 // FormatDoc formats the struct documentation indenting it with passed indent string.
 func (s *StructInfo) FormatDoc(indent string) string {
 	commentPrefix := indent + "// "
@@ -78,13 +151,17 @@ func (s *StructInfo) ParseDefaultsMethod() error {
 					s.Package.TypesInfo.Types[funcDecl.Type.Results.List[0].Type].Type.String())
 			}
 
+			structType := s.Type()
+
 			var defaults interface{}
 			ast.Inspect(funcDecl, func(node ast.Node) bool {
 				switch n := node.(type) {
 				case *ast.CompositeLit:
-					var ident *ast.Ident
-					if ident, ok = n.Type.(*ast.Ident); ok && ident.Name == s.Name {
-						defaults = s.parseDefaultsMethodBody(n)
+					// Match the root literal by type, so that qualified or aliased
+					// type expressions are recognized too.
+					if litType := s.Package.TypesInfo.TypeOf(n); litType != nil &&
+						types.Identical(litType, structType) {
+						defaults = s.parseDefaultsExpr(n)
 						// Stop traversing.
 						return false
 					}
@@ -94,69 +171,244 @@ func (s *StructInfo) ParseDefaultsMethod() error {
 				return true
 			})
 
-			s.Defaults = defaults.(map[string]interface{})
+			if defaults != nil {
+				s.Defaults = defaults.(map[string]interface{})
+			}
 		}
 	}
 
 	return nil
 }
 
-// ParseDefaultsMethod parses recursively the composite literals of Defaults method. It returns a map of
-// fields names-values or an array of values.
-func (s *StructInfo) parseDefaultsMethodBody(lit *ast.CompositeLit) interface{} {
-	var values interface{}
-	isOrdered := false
-	switch lit.Type.(type) {
-	case *ast.ArrayType:
-		values = make([]interface{}, 0)
+// parseDefaultsExpr parses recursively an expression of the Defaults method. Composite literals are
+// returned as a map of fields names-values, an ordered map or an array of values; other expressions
+// are returned as constant values, nil when the expression is not constant.
+func (s *StructInfo) parseDefaultsExpr(expr ast.Expr) interface{} {
+	// Constant expressions, type conversions of constants included, are resolved by the type checker.
+	if tv, ok := s.Package.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		return tv.Value
+	}
 
-	case *ast.MapType:
-		// Uses an ordered map to ensure consistent and sorted output according
-		// to the order in which the default values are defined.
-		isOrdered = true
-		values = ordered.NewMap()
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return s.parseDefaultsMethodBody(e)
+
+	case *ast.ParenExpr:
+		return s.parseDefaultsExpr(e.X)
+
+	case *ast.UnaryExpr:
+		// Address of a composite literal, e.g. &Struct{...}.
+		if e.Op == token.AND {
+			return s.parseDefaultsExpr(e.X)
+		}
+
+	case *ast.CallExpr:
+		// Type conversion of a non-constant value, e.g. Hosts([]string{...}).
+		if tv, ok := s.Package.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return s.parseDefaultsExpr(e.Args[0])
+		}
+
+		if value := s.parseTimeDate(e); value != nil {
+			return value
+		}
+
+		// Constructors of types marshaled as text taking the textual form, e.g. net.ParseIP("::1").
+		if MarshalerMethod(s.Package.TypesInfo.TypeOf(e)) != "" && len(e.Args) == 1 {
+			if value := s.Package.TypesInfo.Types[e.Args[0]].Value; value != nil && value.Kind() == constant.String {
+				return value
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseMapKey parses a map key expression of the Defaults method. It returns the key quoted as
+// encoding/json does: strings, also resulting from constructors of types marshaled as text, as
+// they are, typed constants marshaled as text as their text and numbers in decimal format.
+// Non-constant keys and constants marshaled as unknown text are returned as quoted source code.
+func (s *StructInfo) parseMapKey(expr ast.Expr) string {
+	var key string
+	value, ok := s.parseDefaultsExpr(expr).(constant.Value)
+	if keyType := s.Package.TypesInfo.TypeOf(expr); ok && MarshalerMethod(keyType) == "MarshalText" {
+		for _, info := range LookupTypedConsts(types.Unalias(keyType).String()) {
+			if info.Value == value.ExactString() && info.TextUnknown {
+				ok = false
+			} else if info.Value == value.ExactString() {
+				return info.JSON
+			}
+		}
+	}
+
+	switch {
+	case !ok:
+		key = types.ExprString(expr)
+
+	case value.Kind() == constant.String:
+		key = constant.StringVal(value)
 
 	default:
-		values = make(map[string]interface{})
+		key = value.ExactString()
+	}
+
+	quoted, _ := json.Marshal(key)
+	return string(quoted)
+}
+
+// parseTimeDate evaluates a call to time.Date with constant arguments and time.UTC location.
+// It returns the date as RFC 3339 string constant, nil if the call cannot be evaluated.
+func (s *StructInfo) parseTimeDate(call *ast.CallExpr) constant.Value {
+	fun, ok := typeutil.Callee(s.Package.TypesInfo, call).(*types.Func)
+	if !ok || fun.FullName() != "time.Date" || len(call.Args) != 8 {
+		return nil
+	}
+
+	var args [7]int
+	for i, arg := range call.Args[:7] {
+		value := s.Package.TypesInfo.Types[arg].Value
+		if value == nil {
+			return nil
+		}
+
+		n, exact := constant.Int64Val(constant.ToInt(value))
+		if !exact {
+			return nil
+		}
+
+		args[i] = int(n)
+	}
+
+	// Only UTC is supported, the local time zone would make the output machine-dependent.
+	loc, ok := call.Args[7].(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	if obj := s.Package.TypesInfo.Uses[loc.Sel]; obj == nil || obj.Pkg() == nil ||
+		obj.Pkg().Path() != "time" || obj.Name() != "UTC" {
+		return nil
+	}
+
+	date := time.Date(args[0], time.Month(args[1]), args[2], args[3], args[4], args[5], args[6], time.UTC)
+	return constant.MakeString(date.Format(time.RFC3339Nano))
+}
+
+// concreteValue returns the value of a struct literal element wrapped in a ConcreteValue if the
+// field is of interface type and the value a composite literal, as encoding/json encodes the
+// dynamic value. Other values are returned as they are.
+func (s *StructInfo) concreteValue(field *types.Var, expr ast.Expr, value interface{}) interface{} {
+	if value == nil {
+		return value
+	}
+
+	if _, isInterface := field.Type().Underlying().(*types.Interface); !isInterface {
+		return value
+	}
+
+	if _, isConstant := value.(constant.Value); isConstant {
+		return value
+	}
+
+	return &ConcreteValue{Type: s.Package.TypesInfo.TypeOf(expr), Value: value}
+}
+
+// parseDefaultsMethodBody parses recursively the composite literals of Defaults method. It returns a map of
+// fields names-values, an ordered map or an array of values.
+func (s *StructInfo) parseDefaultsMethodBody(lit *ast.CompositeLit) interface{} {
+	litType := s.Package.TypesInfo.TypeOf(lit)
+	if pointer, ok := litType.Underlying().(*types.Pointer); ok {
+		// Composite literal with elided &T type, e.g. an element of []*T.
+		litType = pointer.Elem()
 	}
 
-	for _, elt := range lit.Elts {
-		switch el := elt.(type) {
-		case *ast.KeyValueExpr:
-			// values is a key-value pair.
-			var key string
-			switch k := el.Key.(type) {
-			case *ast.Ident:
-				key = fmt.Sprintf("%s", k.Name)
+	switch t := litType.Underlying().(type) {
+	case *types.Slice:
+		return s.parseArrayElts(lit.Elts, t.Elem(), 0)
 
-			case *ast.BasicLit:
-				key = fmt.Sprintf("%s", k.Value)
+	case *types.Array:
+		return s.parseArrayElts(lit.Elts, t.Elem(), int(t.Len()))
+
+	case *types.Map:
+		// Uses an ordered map to ensure consistent and sorted output according
+		// to the order in which the default values are defined.
+		values := ordered.NewMap()
+		for _, elt := range lit.Elts {
+			if el, ok := elt.(*ast.KeyValueExpr); ok {
+				values.Append(s.parseMapKey(el.Key), s.parseDefaultsExpr(el.Value))
 			}
+		}
 
-			var value interface{}
-			switch val := el.Value.(type) {
-			case *ast.CompositeLit:
-				value = s.parseDefaultsMethodBody(val)
+		return values
 
-			default:
-				value = s.Package.TypesInfo.Types[val].Value
+	case *types.Struct:
+		values := make(map[string]interface{})
+		for i, elt := range lit.Elts {
+			// Elements of unkeyed literals are the values of fields in declaration order.
+			field, value := t.Field(i), elt
+			if el, ok := elt.(*ast.KeyValueExpr); ok {
+				ident, _ := el.Key.(*ast.Ident)
+				field, _ = s.Package.TypesInfo.Uses[ident].(*types.Var)
+				value = el.Value
 			}
 
-			if isOrdered {
-				values.(*ordered.Map).Append(key, value)
-			} else {
-				values.(map[string]interface{})[key] = value
+			if field != nil {
+				values[field.Name()] = s.concreteValue(field, value, s.parseDefaultsExpr(value))
+			}
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+// parseArrayElts parses the elements of a slice or array literal of elements of type elem, placing
+// the values of keyed elements at their index. Missing elements, up to length when greater than zero,
+// have the zero value.
+func (s *StructInfo) parseArrayElts(elts []ast.Expr, elem types.Type, length int) []interface{} {
+	values := make([]interface{}, 0, length)
+	index := 0
+	for _, elt := range elts {
+		if el, ok := elt.(*ast.KeyValueExpr); ok {
+			if key := s.Package.TypesInfo.Types[el.Key].Value; key != nil {
+				n, _ := constant.Int64Val(constant.ToInt(key))
+				index = int(n)
 			}
 
-		case *ast.CompositeLit:
-			// values is an array of interfaces.
-			values = append(values.([]interface{}), s.parseDefaultsMethodBody(el))
+			elt = el.Value
+		}
 
-		default:
-			// values is an array of interfaces.
-			values = append(values.([]interface{}), s.Package.TypesInfo.Types[el].Value)
+		for len(values) <= index {
+			values = append(values, zeroValue(elem))
 		}
+
+		values[index] = s.parseDefaultsExpr(elt)
+		index++
+	}
+
+	for len(values) < length {
+		values = append(values, zeroValue(elem))
 	}
 
 	return values
 }
+
+// zeroValue returns the zero value of basic type t as constant, nil for other types.
+func zeroValue(t types.Type) interface{} {
+	basic, ok := t.Underlying().(*types.Basic)
+	switch {
+	case !ok:
+		return nil
+
+	case basic.Info()&types.IsString != 0:
+		return constant.MakeString("")
+
+	case basic.Info()&types.IsBoolean != 0:
+		return constant.MakeBool(false)
+
+	case basic.Info()&types.IsNumeric != 0:
+		return constant.MakeInt64(0)
+	}
+
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/marco-sacchi/go2jsonc/testutils"
 	"go/ast"
 	"go/constant"
+	"go/types"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 type StructInfoMatch struct {
@@ -136,6 +138,192 @@ func TestStructInfoDefaultsMultiPackage(t *testing.T) {
 	})
 }
 
+func TestStructInfoDefaultsSyntax(t *testing.T) {
+	pkgInfo, err := NewPackageInfo("../testdata/defaults", "Syntax")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := pkgInfo.Structs[pkgInfo.Package.PkgPath+".Syntax"]
+
+	endpoint := func(host string, port int64) map[string]interface{} {
+		return map[string]interface{}{
+			"Host": constant.MakeString(host),
+			"Port": constant.MakeInt64(port),
+		}
+	}
+
+	tests := []struct {
+		field string
+		want  interface{}
+	}{
+		{field: "Literal", want: constant.MakeString("literal")},
+		{field: "Constant", want: constant.MakeInt64(2048)},
+		{field: "Converted", want: constant.MakeInt64(3)},
+		{field: "Level", want: constant.MakeInt64(2)},
+		{field: "Timeout", want: constant.MakeInt64(int64(5 * time.Second))},
+		{field: "Started", want: constant.MakeString("2023-03-04T10:30:00Z")},
+		{field: "Paren", want: constant.MakeFloat64(1.5)},
+		{
+			field: "Status",
+			want: map[string]interface{}{
+				"Connected": constant.MakeBool(true),
+				"State":     constant.MakeInt64(int64(network.StateConnected)),
+			},
+		},
+		{
+			field: "StatusPtr",
+			want: map[string]interface{}{
+				"State": constant.MakeInt64(int64(network.StateFailed)),
+			},
+		},
+		{field: "Endpoint", want: endpoint("localhost", 8080)},
+		{field: "Endpoints", want: []interface{}{endpoint("first", 1), endpoint("second", 2)}},
+		{field: "Names", want: []interface{}{constant.MakeString("a"), constant.MakeString("b")}},
+		{field: "Origin", want: endpoint("origin", 80)},
+		{field: "Slots", want: []interface{}{constant.MakeInt64(0), constant.MakeInt64(0), constant.MakeInt64(5)}},
+		{field: "Dynamic", want: nil},
+	}
+
+	if len(s.Defaults) != len(tests) {
+		t.Fatalf("Parsed %d defaults, want %d:\n%+v", len(s.Defaults), len(tests), s.Defaults)
+	}
+
+	for _, test := range tests {
+		value, ok := s.Defaults[test.field]
+		if !ok {
+			t.Fatalf("Missing default for field %s", test.field)
+		}
+
+		if !reflect.DeepEqual(value, test.want) {
+			t.Fatalf("Field %s default mismatch:\n%+v\n\nwant:\n%+v", test.field, value, test.want)
+		}
+	}
+}
+
+func TestStructInfoDefaultsInterface(t *testing.T) {
+	pkgInfo, err := NewPackageInfo("../testdata/plugins", "Plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := pkgInfo.Structs[pkgInfo.Package.PkgPath+".Plugins"]
+
+	concrete, ok := s.Defaults["Output"].(*ConcreteValue)
+	if !ok {
+		t.Fatalf("Default of interface field is not a concrete value: %+v", s.Defaults["Output"])
+	}
+
+	if got, want := concrete.Type.String(), "*"+pkgInfo.Package.PkgPath+".FileSink"; got != want {
+		t.Fatalf("Concrete type mismatch: got %s, want %s", got, want)
+	}
+
+	want := map[string]interface{}{"Path": constant.MakeString("/var/log/app.log")}
+	if !reflect.DeepEqual(concrete.Value, want) {
+		t.Fatalf("Concrete value mismatch:\n%+v\n\nwant:\n%+v", concrete.Value, want)
+	}
+}
+
+func TestStructInfoDefaultsMapKeys(t *testing.T) {
+	pkgInfo, err := NewPackageInfo("../testdata/maps", "Maps")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := pkgInfo.Structs[pkgInfo.Package.PkgPath+".Maps"]
+
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{field: "Ports", want: []string{`"80"`, `"443"`}},
+		{field: "Weights", want: []string{`"1"`, `"2"`}},
+		{field: "Constants", want: []string{`"alpha"`, `"beta"`}},
+		{field: "Escaped", want: []string{`"back\\slash"`, `"tab\there"`}},
+		{field: "Hosts", want: []string{`"10.0.0.1"`}},
+		{field: "Nested", want: []string{`"outer"`}},
+		{field: "Deep", want: []string{`"level"`}},
+		{field: "Levels", want: []string{`"info"`}},
+	}
+
+	for _, test := range tests {
+		var keys []string
+		s.Defaults[test.field].(*ordered.Map).Iterate(func(key string, value interface{}) bool {
+			keys = append(keys, key)
+			return true
+		})
+
+		if !reflect.DeepEqual(keys, test.want) {
+			t.Fatalf("Field %s keys mismatch: got %v, want %v", test.field, keys, test.want)
+		}
+	}
+
+	inner := s.Defaults["Deep"].(*ordered.Map).Value(`"level"`).(*ordered.Map)
+	if !reflect.DeepEqual(inner.Value(`"1"`), []interface{}{constant.MakeString("one")}) {
+		t.Fatalf("Nested map value mismatch: %v", inner)
+	}
+}
+
+func TestStructInfoInline(t *testing.T) {
+	pkgInfo, err := NewPackageInfo("../testdata/inline", "Inline")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := pkgInfo.Structs[pkgInfo.Package.PkgPath+".Inline"]
+
+	tests := []struct {
+		field  string
+		fields []string
+		tags   []map[string]string
+	}{
+		{field: "Limits", fields: []string{"CPU", "Mem"}, tags: []map[string]string{{"json": "cpu"}, {"json": "mem"}}},
+		{field: "Rules", fields: []string{"Path", "Allow"}, tags: []map[string]string{nil, nil}},
+		{field: "Weights", fields: []string{"Value"}, tags: []map[string]string{nil}},
+		{field: "Optional", fields: []string{"Enabled"}, tags: []map[string]string{nil}},
+		{field: "Nested", fields: []string{"Inner"}, tags: []map[string]string{nil}},
+	}
+
+	for i, test := range tests {
+		field := s.Fields[i]
+		if field.Name != test.field {
+			t.Fatalf("Field name mismatch: got %s, want %s", field.Name, test.field)
+		}
+
+		// Inline structs may be element or pointed types.
+		structType := field.Type
+		if field.EltType != nil {
+			structType = field.EltType
+		} else if pointer, ok := structType.(*types.Pointer); ok {
+			structType = pointer.Elem()
+		}
+
+		inline := LookupStructType(structType)
+		if inline == nil {
+			t.Fatalf("Cannot lookup inline struct of field %s", field.Name)
+		}
+
+		if len(inline.Fields) != len(test.fields) {
+			t.Fatalf("Inline struct of field %s has %d fields, want %d", field.Name, len(inline.Fields), len(test.fields))
+		}
+
+		for j, f := range inline.Fields {
+			if f.Name != test.fields[j] || !reflect.DeepEqual(f.Tags, test.tags[j]) {
+				t.Fatalf("Inline struct field mismatch:\n%s\nwant name %s, tags %v", f, test.fields[j], test.tags[j])
+			}
+		}
+	}
+
+	want := map[string]interface{}{
+		"CPU": constant.MakeInt64(2),
+		"Mem": constant.MakeInt64(512),
+	}
+
+	if !reflect.DeepEqual(s.Defaults["Limits"], want) {
+		t.Fatalf("Inline struct defaults mismatch:\n%+v\n\nwant:\n%+v", s.Defaults["Limits"], want)
+	}
+}
+
 func testStructInfo(t *testing.T, pattern string, want []*StructInfoMatch) {
 	pkgs := testutils.LoadPackage(t, pattern)
 
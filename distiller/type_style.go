@@ -0,0 +1,139 @@
+package distiller
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// TypeStyle defines the styles of field types in documentation.
+type TypeStyle int
+
+const (
+	GoTypes          TypeStyle = iota // Go types, qualified by package name (default).
+	QualifiedGoTypes                  // Go types, qualified by package path.
+	JSONTypes                         // JSON types, e.g. string, integer or array of object.
+)
+
+// integerRanges maps the kinds of integers to the range of their values. Ranges of 64-bit and
+// platform dependent integers exceed the integers exactly represented by most JSON parsers,
+// so they are not documented.
+var integerRanges = map[types.BasicKind]string{
+	types.Int8:   "-128..127",
+	types.Int16:  "-32768..32767",
+	types.Int32:  "-2147483648..2147483647",
+	types.Uint8:  "0..255",
+	types.Uint16: "0..65535",
+	types.Uint32: "0..4294967295",
+}
+
+// typeString returns the string representation of t in given style.
+func typeString(t types.Type, style TypeStyle) string {
+	switch style {
+	case QualifiedGoTypes:
+		return qualifiedTypeString(t, (*types.Package).Path)
+
+	case JSONTypes:
+		return jsonTypeString(t)
+	}
+
+	return shortTypeString(t)
+}
+
+// shortTypeString returns the string representation of t, qualifying named types by package name only,
+// also when nested in composite types. Inline structs are shortened to "struct", their fields are
+// documented one by one. Aliases and named types are shown with their declared name.
+func shortTypeString(t types.Type) string {
+	return qualifiedTypeString(t, (*types.Package).Name)
+}
+
+// qualifiedTypeString returns the string representation of t, qualifying named types by qualifier,
+// also when nested in composite types and type arguments. Inline structs are shortened to "struct".
+func qualifiedTypeString(t types.Type, qualifier types.Qualifier) string {
+	switch typ := t.(type) {
+	case *types.Struct:
+		return "struct"
+
+	case *types.Pointer:
+		return "*" + qualifiedTypeString(typ.Elem(), qualifier)
+
+	case *types.Slice:
+		return "[]" + qualifiedTypeString(typ.Elem(), qualifier)
+
+	case *types.Array:
+		return fmt.Sprintf("[%d]%s", typ.Len(), qualifiedTypeString(typ.Elem(), qualifier))
+
+	case *types.Map:
+		return "map[" + qualifiedTypeString(typ.Key(), qualifier) + "]" + qualifiedTypeString(typ.Elem(), qualifier)
+	}
+
+	return types.TypeString(t, qualifier)
+}
+
+// jsonTypeString returns the name of the JSON type encoding values of type t, as by encoding/json.
+// Integers are described with the range of their values and types defining typed constants with
+// the names of the allowed values, or of the flags that can be combined.
+func jsonTypeString(t types.Type) string {
+	t = DerefType(t)
+	if _, ok := t.(*types.Named); ok {
+		if consts := LookupTypedConsts(t.String()); consts != nil && !IsWellKnownType(t) {
+			// Enums encoded as strings, e.g. marshaled as text, are written by value.
+			names := make([]string, len(consts))
+			for i, info := range consts {
+				names[i] = info.Name
+				if strings.HasPrefix(info.JSON, `"`) {
+					names[i] = info.JSON
+				}
+			}
+
+			if IsFlagSet(consts) {
+				return "combination of [" + strings.Join(names, ", ") + "]"
+			}
+
+			return "one of [" + strings.Join(names, ", ") + "]"
+		}
+
+		if MarshalerMethod(t) != "" {
+			return "string"
+		}
+	}
+
+	switch typ := t.Underlying().(type) {
+	case *types.Basic:
+		switch info := typ.Info(); {
+		case info&types.IsBoolean != 0:
+			return "boolean"
+
+		case info&types.IsInteger != 0:
+			if valuesRange, ok := integerRanges[typ.Kind()]; ok {
+				return "integer (" + valuesRange + ")"
+			}
+
+			if info&types.IsUnsigned != 0 {
+				return "non-negative integer"
+			}
+
+			return "integer"
+
+		case info&(types.IsFloat|types.IsComplex) != 0:
+			return "number"
+
+		case info&types.IsString != 0:
+			return "string"
+		}
+
+	case *types.Slice:
+		return "array of " + jsonTypeString(typ.Elem())
+
+	case *types.Array:
+		return "array of " + jsonTypeString(typ.Elem())
+
+	case *types.Map:
+		return "object of " + jsonTypeString(typ.Elem())
+
+	case *types.Struct:
+		return "object"
+	}
+
+	return "any"
+}
@@ -0,0 +1,42 @@
+package distiller
+
+import (
+	"testing"
+)
+
+func TestTypeString(t *testing.T) {
+	pkgInfo, err := NewPackageInfo("../testdata/typenames", "TypeNames")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const path = "github.com/marco-sacchi/go2jsonc/testdata/"
+	want := map[string][3]string{
+		"Enabled": {"bool", "bool", "boolean"},
+		"States":  {"[]network.ConnState", "[]" + path + "multipkg/network.ConnState", "array of one of [StateDisconnected, StateConnecting, StateConnected, StateFailed, StateReconnecting]"},
+		"Weights": {"map[string]float32", "map[string]float32", "object of number"},
+		"Address": {"net.IP", "net.IP", "string"},
+		"Timeout": {"time.Duration", "time.Duration", "integer"},
+		"Started": {"time.Time", "time.Time", "string"},
+		"Limits":  {"*typenames.Limits", "*" + path + "typenames.Limits", "object"},
+		"Defaults": {"map[string]typenames.Option[[]typenames.Limits]",
+			"map[string]" + path + "typenames.Option[[]" + path + "typenames.Limits]", "object of object"},
+		"Extra":    {"any", "any", "any"},
+		"Workers":  {"uint", "uint", "non-negative integer"},
+		"Priority": {"int8", "int8", "integer (-128..127)"},
+	}
+
+	styles := []TypeStyle{GoTypes, QualifiedGoTypes, JSONTypes}
+	for _, field := range pkgInfo.LookupStruct("TypeNames").Fields {
+		w, ok := want[field.Name]
+		if !ok {
+			continue
+		}
+
+		for i, style := range styles {
+			if got := typeString(field.Type, style); got != w[i] {
+				t.Fatalf("Type string mismatch for field %s in style %d: got %q, want %q", field.Name, style, got, w[i])
+			}
+		}
+	}
+}
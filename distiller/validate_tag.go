@@ -0,0 +1,123 @@
+package distiller
+
+import (
+	"go/types"
+	"strings"
+)
+
+// validationFormats lists the validate tag options checking the format of strings with their description.
+var validationFormats = []struct {
+	option      string
+	description string
+}{
+	{"email", "email address"},
+	{"url", "URL"},
+	{"uri", "URI"},
+	{"ip", "IP address"},
+	{"ipv4", "IPv4 address"},
+	{"ipv6", "IPv6 address"},
+	{"cidr", "CIDR notation"},
+	{"hostname", "hostname"},
+	{"uuid", "UUID"},
+}
+
+// validationOptions returns the options of the validate tag of the field, as used by
+// github.com/go-playground/validator, applying to the field itself. Options applying to
+// the elements of slices and maps, following dive, and alternatives joined by | are excluded.
+func (f *FieldInfo) validationOptions() map[string]string {
+	tag, ok := f.Tags["validate"]
+	if !ok {
+		return nil
+	}
+
+	options := make(map[string]string)
+	for _, option := range strings.Split(tag, ",") {
+		if option == "dive" {
+			break
+		}
+
+		if strings.Contains(option, "|") {
+			continue
+		}
+
+		name, value, _ := strings.Cut(option, "=")
+		options[name] = value
+	}
+
+	return options
+}
+
+// validationNotes returns the documentation notes describing the constraints of the validate tag
+// of the field, excluding required. Unrecognized options are ignored.
+func (f *FieldInfo) validationNotes() []string {
+	options := f.validationOptions()
+	if options == nil {
+		return nil
+	}
+
+	// Bounds of strings, slices and maps apply to the length.
+	length := false
+	switch t := DerefType(f.Type).Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+		length = true
+
+	case *types.Basic:
+		length = t.Info()&types.IsString != 0
+	}
+
+	bound := func(note string) string {
+		if !length {
+			return note
+		}
+
+		name, value, _ := strings.Cut(note, ": ")
+		switch name {
+		case "Range":
+			return "Length: " + value
+
+		case "Minimum", "Maximum":
+			return name + " length: " + value
+		}
+
+		return "Length " + strings.ToLower(name) + ": " + value
+	}
+
+	var notes []string
+	if value, ok := options["len"]; ok {
+		notes = append(notes, "Length: "+value)
+	}
+
+	minValue, hasMin := options["min"]
+	if gte, ok := options["gte"]; ok && !hasMin {
+		minValue, hasMin = gte, true
+	}
+
+	maxValue, hasMax := options["max"]
+	if lte, ok := options["lte"]; ok && !hasMax {
+		maxValue, hasMax = lte, true
+	}
+
+	if hasMin || hasMax {
+		notes = append(notes, bound(rangeNote(minValue, maxValue)))
+	}
+
+	if gt, ok := options["gt"]; ok {
+		notes = append(notes, bound("Greater than: "+gt))
+	}
+
+	if lt, ok := options["lt"]; ok {
+		notes = append(notes, bound("Less than: "+lt))
+	}
+
+	if oneOf, ok := options["oneof"]; ok {
+		notes = append(notes, "One of: "+strings.Join(strings.Fields(oneOf), ", "))
+	}
+
+	for _, format := range validationFormats {
+		if _, ok := options[format.option]; ok {
+			notes = append(notes, "Format: "+format.description)
+		}
+	}
+
+	return notes
+}
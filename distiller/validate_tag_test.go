@@ -0,0 +1,46 @@
+package distiller
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestFieldInfo_validationNotes(t *testing.T) {
+	intType := types.Typ[types.Int]
+	stringType := types.Typ[types.String]
+
+	tests := []struct {
+		t        types.Type
+		tag      string
+		want     []string
+		required bool
+	}{
+		{intType, "required,min=1,max=65535", []string{"Range: 1..65535"}, true},
+		{intType, "gte=1", []string{"Minimum: 1"}, false},
+		{intType, "min=1,gte=5", []string{"Minimum: 1"}, false},
+		{intType, "lte=10,gt=0", []string{"Maximum: 10", "Greater than: 0"}, false},
+		{types.NewPointer(intType), "lt=10", []string{"Less than: 10"}, false},
+		{stringType, "min=3,max=32", []string{"Length: 3..32"}, false},
+		{stringType, "len=6", []string{"Length: 6"}, false},
+		{stringType, "gt=2", []string{"Length greater than: 2"}, false},
+		{stringType, "oneof=fast safe", []string{"One of: fast, safe"}, false},
+		{stringType, "omitempty,email,uuid", []string{"Format: email address", "Format: UUID"}, false},
+		{stringType, "ipv4|ipv6", nil, false},
+		{types.NewSlice(stringType), "required,min=1,dive,hostname", []string{"Minimum length: 1"}, true},
+		{types.NewMap(stringType, intType), "max=10", []string{"Maximum length: 10"}, false},
+		{intType, "", nil, false},
+	}
+
+	for _, test := range tests {
+		field := &FieldInfo{Type: test.t, Tags: map[string]string{"validate": test.tag}}
+		if notes := field.validationNotes(); !reflect.DeepEqual(notes, test.want) {
+			t.Fatalf("Validation notes of %s tag %q mismatch: got %q, want %q", test.t, test.tag, notes, test.want)
+		}
+
+		if field.IsRequired() != test.required {
+			t.Fatalf("Field of %s tag %q required mismatch: got %v, want %v",
+				test.t, test.tag, field.IsRequired(), test.required)
+		}
+	}
+}
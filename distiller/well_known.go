@@ -0,0 +1,76 @@
+package distiller
+
+import (
+	"go/types"
+)
+
+// wellKnownTypes maps fully qualified names of types having a dedicated JSON representation
+// to the description of the expected format, rendered in fields documentation.
+var wellKnownTypes = map[string]string{
+	"time.Duration": "Duration in nanoseconds, e.g. 1500000000 for 1.5s.",
+	"time.Time":     "Date and time in RFC 3339 format, e.g. \"2006-01-02T15:04:05Z\".",
+}
+
+// IsWellKnownType reports whether t has a dedicated JSON representation, so that it must not
+// be rendered structurally nor looked up for typed constants.
+func IsWellKnownType(t types.Type) bool {
+	_, ok := wellKnownTypes[types.Unalias(t).String()]
+	return ok
+}
+
+// WellKnownFormat returns the description of the JSON format expected for t, an empty string
+// if t is not a well-known type.
+func WellKnownFormat(t types.Type) string {
+	return wellKnownTypes[types.Unalias(t).String()]
+}
+
+// MarshalerMethod returns the name of the method used by encoding/json to marshal values of type t,
+// MarshalJSON or MarshalText, looking also for methods with pointer receiver. It returns an empty
+// string if t implements neither json.Marshaler nor encoding.TextMarshaler.
+func MarshalerMethod(t types.Type) string {
+	if t == nil {
+		return ""
+	}
+
+	t = types.Unalias(t)
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = types.Unalias(pointer.Elem())
+	}
+
+	// Same precedence applied by encoding/json.
+	for _, name := range []string{"MarshalJSON", "MarshalText"} {
+		if hasMarshalerMethod(t, name) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// hasMarshalerMethod reports whether t or *t has a method with given name and the signature:
+//
+//	func() ([]byte, error)
+func hasMarshalerMethod(t types.Type, name string) bool {
+	var pkg *types.Package
+	if named, ok := t.(*types.Named); ok {
+		pkg = named.Obj().Pkg()
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, pkg, name)
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := method.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 2 {
+		return false
+	}
+
+	bytes, ok := sig.Results().At(0).Type().(*types.Slice)
+	if !ok || !types.Identical(bytes.Elem(), types.Typ[types.Byte]) {
+		return false
+	}
+
+	return types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,54 @@
+package distiller
+
+import (
+	"github.com/marco-sacchi/go2jsonc/testutils"
+	"go/types"
+	"testing"
+)
+
+func TestMarshalerMethod(t *testing.T) {
+	pkgs := testutils.LoadPackage(t, "../testdata/marshal")
+	scope := pkgs[0].Types.Scope()
+
+	tests := []struct {
+		typeName string
+		want     string
+	}{
+		{typeName: "URL", want: "MarshalText"},
+		{typeName: "LogLevel", want: "MarshalText"},
+		{typeName: "Raw", want: "MarshalJSON"},
+		{typeName: "Marshal", want: ""},
+	}
+
+	for _, test := range tests {
+		method := MarshalerMethod(scope.Lookup(test.typeName).Type())
+		if method != test.want {
+			t.Fatalf("Marshaler method mismatch for type %s: got %q, want %q", test.typeName, method, test.want)
+		}
+	}
+}
+
+func TestWellKnownType(t *testing.T) {
+	pkgs := testutils.LoadPackage(t, "../testdata/wellknown")
+	times := pkgs[0].Types.Scope().Lookup("Times").Type().Underlying().(*types.Struct)
+
+	want := map[string]bool{
+		"Timeout":  true,
+		"Interval": true,
+		"Backoff":  false,
+		"Started":  true,
+		"Created":  true,
+		"Expires":  false,
+	}
+
+	for i := 0; i < times.NumFields(); i++ {
+		field := times.Field(i)
+		if IsWellKnownType(field.Type()) != want[field.Name()] {
+			t.Fatalf("Well-known type mismatch for field %s of type %s", field.Name(), field.Type())
+		}
+
+		if (WellKnownFormat(field.Type()) != "") != want[field.Name()] {
+			t.Fatalf("Well-known format mismatch for field %s of type %s", field.Name(), field.Type())
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"go/types"
 	"log"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/marco-sacchi/go2jsonc/distiller"
 	"github.com/marco-sacchi/go2jsonc/ordered"
@@ -24,30 +26,148 @@ const (
 	NotMapFields                             // Don't show type on map fields.
 )
 
-var docTypesMode = AllFields
+// DeprecatedMode defines rendering modes for deprecated fields.
+type DeprecatedMode int
+
+const (
+	CommentDeprecated DeprecatedMode = iota // Render deprecated fields commented out (default).
+	OmitDeprecated                          // Don't render deprecated fields.
+	RenderDeprecated                        // Render deprecated fields as the others.
+)
+
+// ExamplesMode defines rendering modes for the examples of fields without default value.
+type ExamplesMode int
+
+const (
+	LiveExamples    ExamplesMode = iota // Render examples as values (default).
+	CommentExamples                     // Render examples commented out, with empty or null values.
+)
+
+// BlankLinesMode defines policies of blank lines between fields.
+type BlankLinesMode int
+
+const (
+	BlankLinesDocumented BlankLinesMode = iota // Separate documented fields by blank lines (default).
+	BlankLinesAlways                           // Separate all fields by blank lines.
+	BlankLinesNever                            // Don't separate fields by blank lines.
+)
+
+// Options controls the generation of JSONC code.
+type Options struct {
+	DocTypes   DocTypesMode             // Rendering of field types in comments.
+	Types      distiller.TypeStyle      // Style of field types in comments.
+	Naming     distiller.NamingStrategy // Naming of the keys of fields without a name in the json tag.
+	Deprecated DeprecatedMode           // Rendering of deprecated fields.
+	Examples   ExamplesMode             // Rendering of examples of fields without default value.
+
+	Indent        int            // Number of spaces of each indentation level, a tab when zero.
+	TabWidth      int            // Width of tabs to compute the width of lines, 4 when zero.
+	LineWidth     int            // Maximum width of documentation lines, longer lines are wrapped; no limit when zero.
+	BlockComments bool           // Render documentation as block comments instead of line comments.
+	BlankLines    BlankLinesMode // Policy of blank lines between fields.
+
+	// Renders single-line documentation as comments trailing the values, aligned in columns;
+	// multi-line documentation is still rendered above the keys.
+	TrailingComments bool
+
+	// Disables the detection of secret fields by name, e.g. Password or APIKey, so that
+	// only the fields marked by directive or jsonc tag are secret.
+	NoSecretHeuristics bool
+}
+
+// options holds the options of the current generation.
+var options Options
+
+// indentUnit is the string of each indentation level.
+var indentUnit = "\t"
+
+// trailingCommentMark separates the entries from their trailing comment until they are aligned.
+const trailingCommentMark = "\x00"
+
+// wellKnownZeros maps well-known types to their zero value, as encoded by encoding/json.
+var wellKnownZeros = map[string]interface{}{
+	"time.Duration": int64(0),
+	"time.Time":     constant.MakeString(time.Time{}.Format(time.RFC3339Nano)),
+}
+
+// expandingInterfaces holds the interface types for which implementations are being rendered.
+var expandingInterfaces = make(map[string]bool)
+
+// jsonLiteral is a raw JSON literal, rendered verbatim.
+type jsonLiteral string
+
+// jsonNull is the JSON null literal.
+const jsonNull jsonLiteral = "null"
+
+// renderingSecrets is set while rendering the fields of a secret struct.
+var renderingSecrets = false
+
+// keyPath holds the keys enclosing the field being rendered, suffixed by [] for arrays and .* for maps.
+var keyPath []string
+
+// fieldPath holds the Go names of the fields enclosing the field being rendered, embedded ones excluded.
+var fieldPath []string
+
+// requiredKeys collects the paths of the required keys, in rendering order.
+var requiredKeys []string
 
 // Generate generates JSONC indented code for given package dir and type name.
 // mode controls the rendering of field types in JSONC comments.
 func Generate(dir, typeName string, mode DocTypesMode) (string, error) {
+	return GenerateWithOptions(dir, typeName, Options{DocTypes: mode})
+}
+
+// GenerateWithOptions generates JSONC indented code for given package dir and type name, rendered
+// as controlled by opts.
+func GenerateWithOptions(dir, typeName string, opts Options) (string, error) {
 	pkgInfo, err := distiller.NewPackageInfo(dir, typeName)
 	if err != nil {
 		return "", err
 	}
 
-	s := distiller.LookupStruct(pkgInfo.Package.PkgPath + "." + typeName)
+	s := pkgInfo.LookupStruct(typeName)
 	if s == nil {
 		return "", fmt.Errorf("cannot find struct %s in package %s", typeName, pkgInfo.Package.Name)
 	}
 
-	docTypesMode = mode
+	options = opts
+	indentUnit = "\t"
+	if options.Indent > 0 {
+		indentUnit = strings.Repeat(" ", options.Indent)
+	}
+
+	keyPath = nil
+	fieldPath = nil
+	requiredKeys = nil
 
 	var code string
 	code, err = renderStruct(s, s.Defaults, "", false, nil)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+
+	header := renderRequiredKeys()
+	if options.BlockComments {
+		header = blockComment(header, "")
+	}
+
+	return header + alignTrailingComments(removeTrailingCommas(code)), nil
+}
+
+// renderRequiredKeys renders the summary comment listing the required keys, an empty string
+// if there are none.
+func renderRequiredKeys() string {
+	if len(requiredKeys) == 0 {
+		return ""
 	}
 
-	return code, nil
+	var builder strings.Builder
+	builder.WriteString("// Required keys:\n")
+	for _, key := range requiredKeys {
+		builder.WriteString("// - " + key + "\n")
+	}
+
+	return builder.String()
 }
 
 // renderStruct renders JSONC indented code for specified struct and all nested or embedded ones recursively.
@@ -57,7 +177,7 @@ func renderStruct(info *distiller.StructInfo, defaults interface{}, indent strin
 
 	if !embedded {
 		builder.WriteString("{\n")
-		indent += "\t"
+		indent += indentUnit
 	}
 
 	var shadowing []string
@@ -78,35 +198,139 @@ func renderStruct(info *distiller.StructInfo, defaults interface{}, indent strin
 			continue
 		}
 
-		builder.WriteString(comma)
+		jsoncTag, err := field.JSONCTag()
+		if err != nil {
+			return "", fmt.Errorf("invalid tag of field %s.%s: %w", info.Name, field.Name, err)
+		}
+
+		// Deprecated embedded structs are rendered as the others, their fields are in the enclosing object.
+		deprecated := field.IsDeprecated() && !field.IsEmbedded && options.Deprecated != RenderDeprecated
 
-		if jsonName, ok := field.Tags["json"]; ok == true {
-			name = jsonName
+		// Ignored fields still shadow the others, as they do when decoding.
+		if _, ignore := field.Directive(distiller.DirectiveIgnore); ignore || (jsoncTag != nil && jsoncTag.Hidden) ||
+			field.Tags["json"] == "-" || (deprecated && options.Deprecated == OmitDeprecated) {
+			continue
+		}
+
+		if comma != "" {
+			// The comma precedes the trailing comment of the previous entry, if any.
+			code := builder.String()
+			builder.Reset()
+			builder.WriteString(withComma(code) + strings.TrimPrefix(comma, ","))
+		}
+
+		if jsoncTag != nil && jsoncTag.Section != "" {
+			// Sections are separated by blank lines.
+			if comma != "" && !blockSpacing {
+				builder.WriteString("\n")
+			}
+
+			builder.WriteString(fmt.Sprintf("%s// ===== %s =====\n\n", indent, jsoncTag.Section))
+			blockSpacing = true
+		}
+
+		name = field.KeyName(options.Naming)
+
+		if directiveName := field.Directives[distiller.DirectiveName]; directiveName != "" {
+			name = directiveName
 		}
 
 		key := field.Name
 		if field.IsEmbedded {
-			key = field.Type.String()
-			if pathEnd := strings.LastIndex(key, "/"); pathEnd >= 0 {
-				key = key[pathEnd+strings.Index(key[pathEnd+1:], ".")+2:]
-			}
+			key = embeddedName(field.Type)
 		}
 
 		var value interface{}
 		ok := false
 		if defaults != nil {
 			value, ok = defaults.(map[string]interface{})[key]
+			// Non-constant expressions have no value, handle them as undefined.
+			ok = ok && value != nil
 		}
 
-		consts := distiller.LookupTypedConsts(field.Type.String())
+		// Pointer fields are rendered as the pointed value.
+		fieldType := distiller.DerefType(field.Type)
+		wellKnown := distiller.IsWellKnownType(fieldType)
 
-		renderType := docTypesMode != NotFields
+		var consts []*distiller.ConstInfo
+		if !wellKnown {
+			consts = distiller.LookupTypedConsts(fieldType.String())
+		}
+
+		renderType := options.DocTypes != NotFields
+
+		// Types marshaling themselves, other than enums, are rendered as strings.
+		textual := !wellKnown && consts == nil && distiller.MarshalerMethod(fieldType) != ""
+		iface, isInterface := fieldType.Underlying().(*types.Interface)
+		if field.EltType != nil {
+			// Implementations are listed for slices and maps of interfaces too.
+			iface, _ = distiller.DerefType(field.EltType).Underlying().(*types.Interface)
+		}
+
+		// No default defined for this field, if struct or array will be rendered below.
+		_, isStruct := fieldType.Underlying().(*types.Struct)
+		isStruct = isStruct && !wellKnown && !textual
 
-		// No default defined for this field, if named (struct) or array will be rendered below.
-		_, isNamed := field.Type.(*types.Named)
-		if !ok && field.Layout == distiller.LayoutSingle && (consts != nil || !isNamed) {
-			if consts != nil {
-				value = consts[0].Value
+		// Fields of embedded structs are keys of the enclosing object.
+		previousPath := keyPath
+		previousFieldPath := fieldPath
+		previousRequiredKeys := requiredKeys
+		if !field.IsEmbedded {
+			pathKey := name
+			switch field.Layout {
+			case distiller.LayoutArray:
+				pathKey += "[]"
+			case distiller.LayoutMap:
+				pathKey += ".*"
+			}
+
+			keyPath = append(keyPath[:len(keyPath):len(keyPath)], pathKey)
+			fieldPath = append(fieldPath[:len(fieldPath):len(fieldPath)], field.Name)
+			if field.IsRequired() && !deprecated {
+				addRequiredKey(strings.Join(append(previousPath[:len(previousPath):len(previousPath)], name), "."))
+			}
+		}
+
+		env, secret := field.Secret(!options.NoSecretHeuristics)
+		secret = secret || renderingSecrets
+		if secret && env == "" {
+			env = envName(append(previousFieldPath[:len(previousFieldPath):len(previousFieldPath)], field.Name))
+		}
+		// Code of the example rendered commented out, if any.
+		exampleCode := ""
+		commentExamples := options.Examples == CommentExamples && !ok && !field.IsEmbedded
+
+		example, hasExample := field.Directive(distiller.DirectiveExample)
+		if !hasExample && jsoncTag != nil && jsoncTag.Example != "" {
+			example, hasExample = jsoncTag.Example, true
+		}
+		if secret && field.Layout == distiller.LayoutSingle && !isStruct {
+			value = jsonLiteral(fmt.Sprintf(`"<set via env %s>"`, env))
+		} else if secret && field.Layout == distiller.LayoutArray {
+			// Elements are not shown, their type is documented.
+			value = jsonLiteral("[]")
+		} else if secret && field.Layout == distiller.LayoutMap {
+			value = jsonLiteral("{}")
+		} else if hasExample && !ok && !field.IsEmbedded {
+			// Examples are JSON literals, rendered verbatim.
+			value = jsonLiteral(example)
+			if commentExamples {
+				exampleCode, value = example, emptyValue(field)
+			}
+		} else if isInterface && field.Layout == distiller.LayoutSingle {
+			// Without a default the concrete type cannot be determined, implementations are
+			// rendered as examples.
+			var err error
+			value, err = renderConcreteValue(value, indent)
+			if err != nil {
+				return "", err
+			}
+		} else if textual && field.Layout == distiller.LayoutSingle {
+			value = textualValue(field.Type, value)
+		} else if !ok && field.Layout == distiller.LayoutSingle && (consts != nil || !isStruct) {
+			// Unset values are the zero value, named by the matching constant if any.
+			if _, isPointer := types.Unalias(field.Type).(*types.Pointer); consts != nil && !isPointer {
+				value = constJSON(consts, zeroConstant(fieldType))
 			} else {
 				value = typeZero(field)
 			}
@@ -114,34 +338,65 @@ func renderStruct(info *distiller.StructInfo, defaults interface{}, indent strin
 			var err error
 			switch field.Layout {
 			case distiller.LayoutSingle:
-				if isNamed && consts == nil {
-					subInfo := distiller.LookupStruct(field.Type.String())
+				if isStruct && consts == nil {
+					subInfo := distiller.LookupStructType(fieldType)
 					if subInfo == nil {
-						return "", fmt.Errorf("cannot lookup structure %s", field.Type.String())
+						return "", fmt.Errorf("cannot lookup structure %s", fieldType.String())
 					}
 
-					renderType = renderType && ((docTypesMode & NotStructFields) == 0)
+					renderType = renderType && ((options.DocTypes & NotStructFields) == 0)
+
+					// Optional sections, not defined by defaults, are examples.
+					if _, isPointer := types.Unalias(field.Type).(*types.Pointer); isPointer && commentExamples {
+						exampleCode, err = renderStruct(subInfo, nil, "", false, nil)
+						value = jsonNull
+						break
+					}
 
+					// All the values of secret structs are replaced by placeholders.
+					previous := renderingSecrets
+					renderingSecrets = secret
 					value, err = renderStruct(subInfo, value, indent, field.IsEmbedded, shadowing[i:])
+					renderingSecrets = previous
 					if err != nil {
 						return "", err
 					}
+				} else if consts != nil {
+					value = constJSON(consts, value)
 				}
 
 				// No special handling required for basic types.
 
 			case distiller.LayoutArray:
-				renderType = renderType && ((docTypesMode & NotArrayFields) == 0)
-				if value == nil {
+				renderType = renderType && ((options.DocTypes & NotArrayFields) == 0)
+				if value == nil && commentExamples {
+					exampleCode, err = renderArray(field.EltType, []interface{}{nil}, "")
+					value = jsonLiteral("[]")
+				} else if value == nil {
 					// Add an example item in case of nil array.
-					value, err = renderArray(field, []interface{}{nil}, indent)
+					value, err = renderArray(field.EltType, []interface{}{nil}, indent)
 				} else {
-					value, err = renderArray(field, value.([]interface{}), indent)
+					value, err = renderArray(field.EltType, value.([]interface{}), indent)
 				}
 
 			case distiller.LayoutMap:
-				renderType = renderType && ((docTypesMode & NotMapFields) == 0)
-				value, err = renderMap(field, value.(*ordered.Map), indent)
+				if field.IsEmbedded {
+					return "", fmt.Errorf("field of slice or map type cannot be embedded")
+				}
+
+				renderType = renderType && ((options.DocTypes & NotMapFields) == 0)
+				if value == nil && commentExamples {
+					elts := ordered.NewMap()
+					elts.Append(exampleKey(field.KeyType), nil)
+					exampleCode, err = renderMap(field.EltType, elts, "")
+					value = jsonLiteral("{}")
+					break
+				}
+
+				if value == nil {
+					value = ordered.NewMap()
+				}
+				value, err = renderMap(field.EltType, value.(*ordered.Map), indent)
 			}
 
 			if err != nil {
@@ -149,11 +404,76 @@ func renderStruct(info *distiller.StructInfo, defaults interface{}, indent strin
 			}
 		}
 
+		keyPath = previousPath
+		fieldPath = previousFieldPath
+		if deprecated {
+			// Keys of deprecated fields cannot be required.
+			requiredKeys = previousRequiredKeys
+		}
+
+		// Deprecated fields are commented out on a single line.
+		if _, collapse := field.Directive(distiller.DirectiveCollapse); (collapse || deprecated) && !field.IsEmbedded {
+			value = collapseCode(fmt.Sprintf("%v", value))
+		}
+
 		if field.IsEmbedded {
 			builder.WriteString(fmt.Sprintf("%v", value))
 		} else {
-			doc := field.FormatDoc(indent, renderType)
-			if doc != "" {
+			if display, ok := field.Directive(distiller.DirectiveType); ok {
+				if display != "show" && display != "hide" {
+					return "", fmt.Errorf("invalid type directive of field %s.%s: %q", info.Name, field.Name, display)
+				}
+
+				renderType = display == "show"
+			}
+
+			doc := field.FormatDocWith(indent, renderType, distiller.DocFormat{
+				LineWidth: options.LineWidth,
+				TabWidth:  options.TabWidth,
+				Types:     options.Types,
+			})
+			if iface != nil {
+				examples, err := renderImplementations(info, field, iface, indent)
+				if err != nil {
+					return "", err
+				}
+
+				doc += examples
+			}
+
+			if secret {
+				doc += secretNote(field, isStruct, env, indent)
+			}
+
+			if exampleCode != "" {
+				doc += renderExample(name, exampleCode, indent)
+			}
+
+			if options.BlockComments {
+				doc = blockComment(doc, indent)
+			}
+
+			// Documentation of flags is never trailing, allowed values are listed.
+			trailing := ""
+			if consts != nil && field.Layout == distiller.LayoutSingle {
+				trailing = flagsComment(consts, fmt.Sprintf("%v", value))
+			}
+
+			if options.TrailingComments && strings.Count(doc, "\n") == 1 &&
+				!strings.Contains(fmt.Sprintf("%v", value), "\n") {
+				trailing, doc = trailingCommentMark+strings.TrimSpace(doc), ""
+			}
+
+			spaced := doc != ""
+			switch options.BlankLines {
+			case BlankLinesAlways:
+				spaced = true
+
+			case BlankLinesNever:
+				spaced = false
+			}
+
+			if spaced {
 				// Adds a blank line when the comment block is present.
 				if !blockSpacing && (comma != "") {
 					builder.WriteString("\n")
@@ -164,7 +484,12 @@ func renderStruct(info *distiller.StructInfo, defaults interface{}, indent strin
 			}
 
 			builder.WriteString(doc)
-			builder.WriteString(fmt.Sprintf("%s\"%s\": %v", indent, name, value))
+			entryIndent := indent
+			if deprecated {
+				entryIndent += "// "
+			}
+
+			builder.WriteString(fmt.Sprintf("%s\"%s\": %v%s", entryIndent, name, value, trailing))
 		}
 
 		comma = ",\n"
@@ -178,55 +503,72 @@ func renderStruct(info *distiller.StructInfo, defaults interface{}, indent strin
 			builder.WriteString("\n")
 		}
 
-		builder.WriteString(indent[:len(indent)-1] + "}")
+		builder.WriteString(strings.TrimSuffix(indent, indentUnit) + "}")
 	}
 
 	return builder.String(), nil
 }
 
-// renderArray renders slice or array fields.
-func renderArray(field *distiller.FieldInfo, value []interface{}, indent string) (string, error) {
+// renderArray renders slice or array values with elements of given type.
+func renderArray(eltType types.Type, value []interface{}, indent string) (string, error) {
 	if len(value) == 0 {
 		return "[]", nil
 	}
 
-	eltsIdent := indent + "\t"
-	code := "[\n"
-	for _, elt := range value {
-		literal, err := renderElement(field.EltType, elt, eltsIdent)
+	eltsIdent := indent + indentUnit
+	elts := make([]string, len(value))
+	for i, elt := range value {
+		literal, err := renderElement(eltType, elt, eltsIdent)
 		if err != nil {
 			return "", err
 		}
 
-		code += eltsIdent + literal + ",\n"
+		elts[i] = eltsIdent + literal
 	}
-	code = strings.TrimRight(code, ",\n") + "\n" + indent + "]"
 
-	return code, nil
+	return "[\n" + joinEntries(elts) + "\n" + indent + "]", nil
 }
 
-// renderMap renders map fields.
-func renderMap(field *distiller.FieldInfo, value *ordered.Map, indent string) (string, error) {
-	if field.IsEmbedded == true {
-		return "", fmt.Errorf("field of slice or map type cannot be embedded")
+// renderConcreteValue renders the default value of an interface field as its dynamic value, as
+// encoding/json does: constants as JSON literals and composite literals as structs. It returns null
+// if there is no default or the struct cannot be found.
+func renderConcreteValue(value interface{}, indent string) (interface{}, error) {
+	if c, ok := value.(constant.Value); ok {
+		return jsonLiteral(distiller.ConstantJSON(c)), nil
+	}
+
+	concrete, ok := value.(*distiller.ConcreteValue)
+	if !ok {
+		return jsonNull, nil
+	}
+
+	info := distiller.LookupStructType(distiller.DerefType(concrete.Type))
+	if info == nil {
+		return jsonNull, nil
 	}
 
+	return renderStruct(info, concrete.Value, indent, false, nil)
+}
+
+// renderMap renders map values with elements of given type. Keys are expected to be already
+// quoted as JSON strings.
+func renderMap(eltType types.Type, value *ordered.Map, indent string) (string, error) {
 	if value.Len() == 0 {
 		return "{}", nil
 	}
 
-	eltsIndent := indent + "\t"
-	code := "{\n"
+	eltsIndent := indent + indentUnit
 
+	var elts []string
 	var err error
 	value.Iterate(func(key string, elt interface{}) bool {
 		var literal string
-		literal, err = renderElement(field.EltType, elt, eltsIndent)
+		literal, err = renderElement(eltType, elt, eltsIndent)
 		if err != nil {
 			return false
 		}
 
-		code += eltsIndent + fmt.Sprintf("%s: %s", key, literal) + ",\n"
+		elts = append(elts, eltsIndent+fmt.Sprintf("%s: %s", key, literal))
 		return true
 	})
 
@@ -234,19 +576,73 @@ func renderMap(field *distiller.FieldInfo, value *ordered.Map, indent string) (s
 		return "", err
 	}
 
-	code = strings.TrimRight(code, ",\n") + "\n" + indent + "}"
-
-	return code, nil
+	return "{\n" + joinEntries(elts) + "\n" + indent + "}", nil
 }
 
 // renderElement renders an element value of a slice, array or map.
 func renderElement(itemType types.Type, item interface{}, indent string) (string, error) {
-	_, ok := itemType.(*types.Basic)
-	if ok || distiller.LookupTypedConsts(itemType.String()) != nil {
+	itemType = distiller.DerefType(itemType)
+	if distiller.IsWellKnownType(itemType) {
+		if item == nil {
+			item = wellKnownZeros[itemType.String()]
+		}
+
 		return fmt.Sprintf("%v", item), nil
 	}
 
-	subInfo := distiller.LookupStruct(itemType.String())
+	if _, ok := itemType.Underlying().(*types.Interface); ok {
+		// Only constant values can be rendered without knowing the concrete type.
+		if c, ok := item.(constant.Value); ok {
+			return distiller.ConstantJSON(c), nil
+		}
+
+		return string(jsonNull), nil
+	}
+
+	consts := distiller.LookupTypedConsts(itemType.String())
+	if consts == nil && distiller.MarshalerMethod(itemType) != "" {
+		return fmt.Sprintf("%v", textualValue(itemType, item)), nil
+	}
+
+	_, ok := itemType.Underlying().(*types.Basic)
+	if ok || consts != nil {
+		// Example items of nil slices have the zero value, named by the matching constant if any.
+		if item == nil && consts != nil {
+			item = constJSON(consts, zeroConstant(itemType))
+		} else if consts != nil {
+			item = constJSON(consts, item)
+		} else if item == nil {
+			item = typeZero(&distiller.FieldInfo{Type: itemType, Layout: distiller.LayoutSingle})
+		}
+
+		code := fmt.Sprintf("%v", item)
+		if consts != nil {
+			code += flagsComment(consts, code)
+		}
+
+		return code, nil
+	}
+
+	// Nested slices, arrays and maps.
+	switch t := itemType.Underlying().(type) {
+	case *types.Slice:
+		elts, _ := item.([]interface{})
+		return renderArray(t.Elem(), elts, indent)
+
+	case *types.Array:
+		elts, _ := item.([]interface{})
+		return renderArray(t.Elem(), elts, indent)
+
+	case *types.Map:
+		elts, ok := item.(*ordered.Map)
+		if !ok {
+			elts = ordered.NewMap()
+		}
+
+		return renderMap(t.Elem(), elts, indent)
+	}
+
+	subInfo := distiller.LookupStructType(itemType)
 	if subInfo == nil {
 		return "", fmt.Errorf("cannot lookup structure %s", itemType.String())
 	}
@@ -254,6 +650,370 @@ func renderElement(itemType types.Type, item interface{}, indent string) (string
 	return renderStruct(subInfo, item, indent, false, nil)
 }
 
+// renderImplementations renders as comments an example for each implementation of the interface
+// type of given field: those listed by the oneof directive or, when missing, the ones found in the
+// loaded packages.
+func renderImplementations(info *distiller.StructInfo, field *distiller.FieldInfo, iface *types.Interface,
+	indent string) (string, error) {
+	// Prevents infinite recursion on implementations having fields of the same interface type.
+	key := iface.String()
+	if expandingInterfaces[key] {
+		return "", nil
+	}
+
+	expandingInterfaces[key] = true
+	defer delete(expandingInterfaces, key)
+
+	// Keys of examples are not required by the enclosing struct.
+	defer func(keys []string) {
+		requiredKeys = keys
+	}(requiredKeys)
+
+	var implementations []*distiller.StructInfo
+	if oneOf, ok := field.Directives["oneof"]; ok {
+		for _, name := range strings.Split(oneOf, ",") {
+			name = strings.TrimSpace(name)
+
+			// Names are resolved in the package of the struct, unless qualified by an imported package name.
+			path := info.Package.PkgPath + "." + name
+			if pkgName, typeName, ok := strings.Cut(name, "."); ok {
+				for _, imported := range info.Package.Imports {
+					if imported.Name == pkgName {
+						path = imported.PkgPath + "." + typeName
+					}
+				}
+			}
+
+			implementation := distiller.LookupStruct(path)
+			if implementation == nil {
+				return "", fmt.Errorf("cannot lookup structure %s listed by oneof directive of field %s",
+					name, field.Name)
+			}
+
+			implementations = append(implementations, implementation)
+		}
+	} else {
+		implementations = distiller.LookupImplementations(iface)
+	}
+
+	var builder strings.Builder
+	for _, implementation := range implementations {
+		code, err := renderStruct(implementation, implementation.Defaults, "", false, nil)
+		if err != nil {
+			return "", err
+		}
+
+		builder.WriteString(fmt.Sprintf("%s// %s.%s example:\n", indent,
+			implementation.Package.Name, implementation.Name))
+		for _, line := range strings.Split(code, "\n") {
+			builder.WriteString(strings.TrimRight(indent+"// "+line, " ") + "\n")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// blockComment converts a block of line comments indented by given indent string to a block comment.
+func blockComment(doc string, indent string) string {
+	if doc == "" {
+		return ""
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(doc, "\n"), "\n") {
+		line = strings.TrimPrefix(strings.TrimPrefix(line, indent+"//"), " ")
+		// Block comments cannot be nested.
+		lines = append(lines, strings.ReplaceAll(line, "*/", "* /"))
+	}
+
+	if len(lines) == 1 {
+		return indent + "/* " + lines[0] + " */\n"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(indent + "/*\n")
+	for _, line := range lines {
+		builder.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	builder.WriteString(indent + " */\n")
+
+	return builder.String()
+}
+
+// renderExample renders the commented out example of a field, removing the comments of the example code.
+func renderExample(name string, code string, indent string) string {
+	var lines []string
+	for _, line := range strings.Split(code, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !isCommentLine(trimmed) {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s// Example: \"%s\": %s\n", indent, name, lines[0])
+	}
+
+	var builder strings.Builder
+	builder.WriteString(indent + "// Example:\n")
+	builder.WriteString(fmt.Sprintf("%s// \"%s\": %s\n", indent, name, lines[0]))
+	for _, line := range lines[1:] {
+		builder.WriteString(indent + "// " + line + "\n")
+	}
+
+	return builder.String()
+}
+
+// emptyValue returns the value of a field without elements: an empty array or object for slices
+// and maps, the zero value otherwise.
+func emptyValue(field *distiller.FieldInfo) interface{} {
+	switch field.Layout {
+	case distiller.LayoutArray:
+		return jsonLiteral("[]")
+
+	case distiller.LayoutMap:
+		return jsonLiteral("{}")
+	}
+
+	if _, ok := types.Unalias(field.Type).Underlying().(*types.Struct); ok {
+		return jsonLiteral("{}")
+	}
+
+	return typeZero(field)
+}
+
+// exampleKey returns the JSON-quoted key of the example element of maps with given key type.
+func exampleKey(keyType types.Type) string {
+	if basic, ok := keyType.Underlying().(*types.Basic); ok && basic.Info()&types.IsInteger != 0 {
+		return `"0"`
+	}
+
+	return `"key"`
+}
+
+// secretNote returns the comment explaining how the value of a secret field is set.
+func secretNote(field *distiller.FieldInfo, isStruct bool, env string, indent string) string {
+	note := "Secret, the elements are not shown."
+	switch {
+	case field.Layout == distiller.LayoutSingle && isStruct:
+		note = "Secret, all values are set via environment variables."
+
+	case field.Layout == distiller.LayoutSingle:
+		note = "Secret, set via environment variable " + env + "."
+	}
+
+	return indent + "// " + note + "\n"
+}
+
+// envName returns the name of the environment variable for the field of given path of Go names, in
+// upper snake case independently of the naming of keys, e.g. DB_PASSWORD for DBPassword.
+func envName(path []string) string {
+	words := make([]string, len(path))
+	for i, name := range path {
+		words[i] = distiller.ScreamingSnakeNames.Apply(name)
+	}
+
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, strings.Join(words, "_"))
+}
+
+// collapseCode renders indented JSONC code on a single line, removing comments.
+func collapseCode(code string) string {
+	var builder strings.Builder
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimSpace(line)
+
+		// Trailing comments are dropped, the comma precedes them.
+		line, _, _ = strings.Cut(line, trailingCommentMark)
+
+		if line == "" || isCommentLine(line) {
+			continue
+		}
+
+		// Elements are separated by a space, but not from the enclosing brackets.
+		collapsed := builder.String()
+		if collapsed != "" && !strings.HasSuffix(collapsed, "{") && !strings.HasSuffix(collapsed, "[") &&
+			!strings.HasPrefix(line, "}") && !strings.HasPrefix(line, "]") {
+			builder.WriteString(" ")
+		}
+
+		builder.WriteString(line)
+	}
+
+	return builder.String()
+}
+
+// removeTrailingCommas removes the commas following the last entries of objects and arrays, left
+// when the entries after them are commented out.
+func removeTrailingCommas(code string) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		// The comma precedes the trailing comment, whose text is never parsed.
+		entry, comment, hasComment := strings.Cut(line, trailingCommentMark)
+		trimmed := strings.TrimSpace(entry)
+		if !strings.HasSuffix(trimmed, ",") || isCommentLine(trimmed) {
+			continue
+		}
+
+		for _, next := range lines[i+1:] {
+			next = strings.TrimSpace(next)
+			if next == "" || isCommentLine(next) {
+				continue
+			}
+
+			if strings.HasPrefix(next, "}") || strings.HasPrefix(next, "]") {
+				lines[i] = strings.TrimSuffix(entry, ",")
+				if hasComment {
+					lines[i] += trailingCommentMark + comment
+				}
+			}
+
+			break
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// alignTrailingComments aligns the trailing comments of consecutive entries in a column.
+func alignTrailingComments(code string) string {
+	lines := strings.Split(code, "\n")
+	for start := 0; start < len(lines); {
+		if !strings.Contains(lines[start], trailingCommentMark) {
+			start++
+			continue
+		}
+
+		end := start
+		width := 0
+		for ; end < len(lines) && strings.Contains(lines[end], trailingCommentMark); end++ {
+			entry, _, _ := strings.Cut(lines[end], trailingCommentMark)
+			width = max(width, len(entry))
+		}
+
+		for i := start; i < end; i++ {
+			entry, comment, _ := strings.Cut(lines[i], trailingCommentMark)
+			lines[i] = entry + strings.Repeat(" ", width-len(entry)+1) + comment
+		}
+
+		start = end
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// withComma returns code followed by the comma separating entries, placed before the trailing
+// comment of its last line, if any.
+func withComma(code string) string {
+	start := strings.LastIndex(code, "\n") + 1
+	if i := strings.Index(code[start:], trailingCommentMark); i >= 0 {
+		return code[:start+i] + "," + code[start+i:]
+	}
+
+	return code + ","
+}
+
+// joinEntries joins the lines of object or array entries, separating them by commas.
+func joinEntries(entries []string) string {
+	for i := range entries[:len(entries)-1] {
+		entries[i] = withComma(entries[i])
+	}
+
+	return strings.Join(entries, "\n")
+}
+
+// isCommentLine reports whether a trimmed line is a comment line, or part of a block comment.
+func isCommentLine(line string) bool {
+	return strings.HasPrefix(line, "//") || strings.HasPrefix(line, "/*") || strings.HasPrefix(line, "*")
+}
+
+// addRequiredKey adds the path of a required key to the summary, if not already present,
+// e.g. because required by all elements of an array.
+func addRequiredKey(path string) {
+	for _, key := range requiredKeys {
+		if key == path {
+			return
+		}
+	}
+
+	requiredKeys = append(requiredKeys, path)
+}
+
+// flagsComment returns the trailing comment decomposing value in the combined flags, an empty string
+// if consts are not bit flags or the value matches none of them.
+func flagsComment(consts []*distiller.ConstInfo, value string) string {
+	if !distiller.IsFlagSet(consts) {
+		return ""
+	}
+
+	names := distiller.DecomposeFlags(consts, value)
+	if names == "" {
+		return ""
+	}
+
+	if options.BlockComments {
+		return trailingCommentMark + "/* = " + names + " */"
+	}
+
+	return trailingCommentMark + "// = " + names
+}
+
+// constJSON returns the JSON literal of the typed constant having value or, if no constant matches,
+// e.g. for values not declared as constants, the JSON literal of value itself.
+func constJSON(consts []*distiller.ConstInfo, value interface{}) interface{} {
+	c, ok := value.(constant.Value)
+	if !ok {
+		return value
+	}
+
+	for _, info := range consts {
+		if info.Value == c.ExactString() && info.TextUnknown {
+			// As for values of types marshaled as text, the unknown text is an empty string placeholder.
+			return jsonLiteral(`""`)
+		} else if info.Value == c.ExactString() {
+			return jsonLiteral(info.JSON)
+		}
+	}
+
+	return jsonLiteral(distiller.ConstantJSON(c))
+}
+
+// zeroConstant returns the zero value of the basic type underlying t, e.g. of the type of typed constants.
+func zeroConstant(t types.Type) constant.Value {
+	basic, _ := t.Underlying().(*types.Basic)
+	switch {
+	case basic != nil && basic.Info()&types.IsString != 0:
+		return constant.MakeString("")
+
+	case basic != nil && basic.Info()&types.IsBoolean != 0:
+		return constant.MakeBool(false)
+	}
+
+	return constant.MakeInt64(0)
+}
+
+// embeddedName returns the name of an embedded field of type t, i.e. the name of the type
+// without package and type arguments.
+func embeddedName(t types.Type) string {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	switch typ := t.(type) {
+	case *types.Alias:
+		return typ.Obj().Name()
+
+	case *types.Named:
+		return typ.Obj().Name()
+	}
+
+	return t.String()
+}
+
 // lastIndexOf returns the last slice index of specified value.
 func lastIndexOf(slice []string, value string) int {
 	if slice != nil {
@@ -267,9 +1027,28 @@ func lastIndexOf(slice []string, value string) int {
 	return -1
 }
 
+// textualValue returns the value of a type marshaled as text: the default value when defined
+// as string constant, otherwise an empty string placeholder, or null for pointers.
+func textualValue(t types.Type, value interface{}) interface{} {
+	if c, ok := value.(constant.Value); ok && c.Kind() == constant.String {
+		return c
+	}
+
+	if _, ok := types.Unalias(t).(*types.Pointer); ok && value == nil {
+		return jsonNull
+	}
+
+	return constant.MakeString("")
+}
+
 // typeZero return the default uninitialized value for specified field.
 func typeZero(field *distiller.FieldInfo) interface{} {
 	var value interface{}
+	fieldType := types.Unalias(field.Type)
+	if _, ok := fieldType.(*types.Pointer); ok {
+		return jsonNull
+	}
+
 	if field.Layout == distiller.LayoutArray {
 		value = make([]interface{}, 0)
 		return value
@@ -278,7 +1057,15 @@ func typeZero(field *distiller.FieldInfo) interface{} {
 		return value
 	}
 
-	fieldType := types.Default(field.Type)
+	if zero, ok := wellKnownZeros[fieldType.String()]; ok {
+		return zero
+	}
+
+	if _, ok := fieldType.Underlying().(*types.Interface); ok {
+		return jsonNull
+	}
+
+	fieldType = types.Default(fieldType.Underlying())
 	switch t := fieldType.(type) {
 	case *types.Basic:
 		switch t.Kind() {
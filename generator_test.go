@@ -3,6 +3,7 @@ package go2jsonc
 import (
 	"github.com/marco-sacchi/go2jsonc/distiller"
 	"go/constant"
+	"go/token"
 	"go/types"
 	"os"
 	"reflect"
@@ -22,6 +23,20 @@ func TestGenerator(t *testing.T) {
 		{"./testdata", "Nesting", "./testdata/nesting.jsonc", AllFields},
 		{"./testdata", "Simple", "./testdata/simple.jsonc", AllFields},
 		{"./testdata/multipkg", "MultiPackage", "./testdata/multipkg/multi_package.jsonc", AllFields},
+		{"./testdata/defaults", "Syntax", "./testdata/defaults/syntax.jsonc", AllFields},
+		{"./testdata/wellknown", "Times", "./testdata/wellknown/times.jsonc", AllFields},
+		{"./testdata/marshal", "Marshal", "./testdata/marshal/marshal.jsonc", AllFields},
+		{"./testdata/plugins", "Plugins", "./testdata/plugins/plugins.jsonc", AllFields},
+		{"./testdata/maps", "Maps", "./testdata/maps/maps.jsonc", AllFields},
+		{"./testdata/generics", "Generics", "./testdata/generics/generics.jsonc", AllFields},
+		{"./testdata/inline", "Inline", "./testdata/inline/inline.jsonc", AllFields},
+		{"./testdata/named", "Named", "./testdata/named/named.jsonc", AllFields},
+		{"./testdata/named", "Alias", "./testdata/named/named.jsonc", AllFields},
+		{"./testdata/directives", "Directives", "./testdata/directives/directives.jsonc", AllFields},
+		{"./testdata/tags", "Tags", "./testdata/tags/tags.jsonc", AllFields},
+		{"./testdata/validate", "Validate", "./testdata/validate/validate.jsonc", AllFields},
+		{"./testdata/required", "Required", "./testdata/required/required.jsonc", AllFields},
+		{"./testdata/enums", "Enums", "./testdata/enums/enums.jsonc", AllFields},
 
 		{"./testdata", "Embedding", "./testdata/embedding_not_fields.jsonc", NotFields},
 		{"./testdata", "Nesting", "./testdata/nesting_not_fields.jsonc", NotFields},
@@ -76,9 +91,123 @@ func TestGenerator(t *testing.T) {
 	if err == nil {
 		t.Fatalf("Generating for invalid struct: expected error, got nil.")
 	}
+
+	_, err = Generate("./testdata/tags/invalid", "Invalid", AllFields)
+	if err == nil || !strings.Contains(err.Error(), `unknown jsonc tag option "default"`) {
+		t.Fatalf("Generating for invalid jsonc tag: expected unknown option error, got %v.", err)
+	}
+
+	_, err = Generate("./testdata/typenames/invalid", "Invalid", AllFields)
+	if err == nil || !strings.Contains(err.Error(), `invalid type directive of field Invalid.Name: "bold"`) {
+		t.Fatalf("Generating for invalid type directive: expected invalid directive error, got %v.", err)
+	}
+}
+
+func TestGenerateWithOptions(t *testing.T) {
+	var tests = []struct {
+		pkgDir   string
+		typeName string
+		filename string
+		options  Options
+	}{
+		{"./testdata/deprecated", "Deprecated", "./testdata/deprecated/deprecated.jsonc",
+			Options{Deprecated: CommentDeprecated}},
+		{"./testdata/deprecated", "Deprecated", "./testdata/deprecated/deprecated_omit.jsonc",
+			Options{Deprecated: OmitDeprecated}},
+		{"./testdata/deprecated", "Deprecated", "./testdata/deprecated/deprecated_render.jsonc",
+			Options{Deprecated: RenderDeprecated}},
+		{"./testdata/examples", "Examples", "./testdata/examples/examples.jsonc", Options{}},
+		{"./testdata/examples", "Examples", "./testdata/examples/examples_comment.jsonc",
+			Options{Examples: CommentExamples}},
+		{"./testdata/examples", "Examples", "./testdata/examples/examples_block.jsonc",
+			Options{Examples: CommentExamples, BlockComments: true}},
+		{"./testdata/secrets", "Secrets", "./testdata/secrets/secrets.jsonc", Options{}},
+		{"./testdata/secrets", "Secrets", "./testdata/secrets/secrets_no_heuristics.jsonc",
+			Options{NoSecretHeuristics: true}},
+		{"./testdata/format", "Format", "./testdata/format/format.jsonc", Options{}},
+		{"./testdata/format", "Format", "./testdata/format/format_spaces.jsonc",
+			Options{Indent: 2, LineWidth: 60, BlankLines: BlankLinesAlways}},
+		{"./testdata/format", "Format", "./testdata/format/format_block.jsonc",
+			Options{BlockComments: true, LineWidth: 60, BlankLines: BlankLinesNever}},
+		{"./testdata/format", "Format", "./testdata/format/format_trailing.jsonc", Options{TrailingComments: true}},
+		{"./testdata/format", "Format", "./testdata/format/format_trailing_block.jsonc",
+			Options{TrailingComments: true, BlockComments: true, DocTypes: NotFields, Indent: 2}},
+		{"./testdata/typenames", "TypeNames", "./testdata/typenames/typenames.jsonc",
+			Options{DocTypes: NotStructFields}},
+		{"./testdata/typenames", "TypeNames", "./testdata/typenames/typenames_qualified.jsonc",
+			Options{DocTypes: NotStructFields, Types: distiller.QualifiedGoTypes}},
+		{"./testdata/typenames", "TypeNames", "./testdata/typenames/typenames_json.jsonc",
+			Options{DocTypes: NotStructFields, Types: distiller.JSONTypes}},
+		{"./testdata/enums", "Enums", "./testdata/enums/enums_json.jsonc", Options{Types: distiller.JSONTypes}},
+		{"./testdata/naming", "Naming", "./testdata/naming/naming.jsonc", Options{}},
+		{"./testdata/naming", "Naming", "./testdata/naming/naming_lower_camel.jsonc",
+			Options{Naming: distiller.LowerCamelNames}},
+		{"./testdata/naming", "Naming", "./testdata/naming/naming_snake.jsonc",
+			Options{Naming: distiller.SnakeCaseNames}},
+		{"./testdata/naming", "Naming", "./testdata/naming/naming_kebab.jsonc",
+			Options{Naming: distiller.KebabCaseNames}},
+		{"./testdata/naming", "Naming", "./testdata/naming/naming_screaming_snake.jsonc",
+			Options{Naming: distiller.ScreamingSnakeNames}},
+	}
+
+	whitespacesReplacer := strings.NewReplacer(" ", "◦", "\t", "———➞")
+	for _, test := range tests {
+		jsonc, err := GenerateWithOptions(test.pkgDir, test.typeName, test.options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(test.filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := string(content); jsonc != want {
+			t.Fatalf("Generated JSONC mismatch for %s struct with options %+v:\n%s\n\nwant %s:\n%s",
+				test.typeName, test.options,
+				whitespacesReplacer.Replace(jsonc),
+				test.filename,
+				whitespacesReplacer.Replace(want))
+		}
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	var tests = []struct {
+		pkgDir   string
+		typeName string
+	}{
+		{"./testdata/multipkg", "MultiPackage"},
+		{"./testdata/plugins", "Plugins"},
+		{"./testdata/enums", "Enums"},
+	}
+
+	const runs = 10
+	for _, test := range tests {
+		first, err := GenerateWithOptions(test.pkgDir, test.typeName, Options{DocTypes: AllFields})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 1; i < runs; i++ {
+			jsonc, err := GenerateWithOptions(test.pkgDir, test.typeName, Options{DocTypes: AllFields})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if jsonc != first {
+				t.Fatalf("Generated JSONC for %s struct differs at run %d:\n%s\n\nfirst run:\n%s",
+					test.typeName, i+1, jsonc, first)
+			}
+		}
+	}
 }
 
 func TestGenerator_typeZero(t *testing.T) {
+	timePkg := types.NewPackage("time", "time")
+	durationType := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Duration", nil), types.Typ[types.Int64], nil)
+	timeType := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Time", nil), types.NewStruct(nil, nil), nil)
+
 	tests := []struct {
 		info *distiller.FieldInfo
 		want interface{}
@@ -102,6 +231,9 @@ func TestGenerator_typeZero(t *testing.T) {
 		{info: &distiller.FieldInfo{Type: types.Typ[types.Complex64], Layout: distiller.LayoutSingle}, want: complex64(0)},
 		{info: &distiller.FieldInfo{Type: types.Typ[types.Complex128], Layout: distiller.LayoutSingle}, want: complex128(0)},
 		{info: &distiller.FieldInfo{Type: types.Typ[types.String], Layout: distiller.LayoutSingle}, want: constant.MakeString("")},
+		{info: &distiller.FieldInfo{Type: types.NewPointer(types.Typ[types.Int]), Layout: distiller.LayoutSingle}, want: jsonNull},
+		{info: &distiller.FieldInfo{Type: durationType, Layout: distiller.LayoutSingle}, want: int64(0)},
+		{info: &distiller.FieldInfo{Type: timeType, Layout: distiller.LayoutSingle}, want: constant.MakeString("0001-01-01T00:00:00Z")},
 	}
 
 	for _, test := range tests {
@@ -113,3 +245,20 @@ func TestGenerator_typeZero(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerator_envName(t *testing.T) {
+	tests := []struct {
+		path []string
+		want string
+	}{
+		{path: []string{"DBPassword"}, want: "DB_PASSWORD"},
+		{path: []string{"Database", "APIKey"}, want: "DATABASE_API_KEY"},
+		{path: []string{"Auth", "Token_Secret"}, want: "AUTH_TOKEN_SECRET"},
+	}
+
+	for _, test := range tests {
+		if got := envName(test.path); got != test.want {
+			t.Fatalf("Environment variable name mismatch for path %v: got %s, want %s", test.path, got, test.want)
+		}
+	}
+}
@@ -1,11 +1,10 @@
 module github.com/marco-sacchi/go2jsonc
 
-go 1.17
+go 1.23
 
-require golang.org/x/tools v0.1.10
+require golang.org/x/tools v0.26.0
 
 require (
-	golang.org/x/mod v0.6.0-dev.0.20220106191415-9b9b3d81d5e3 // indirect
-	golang.org/x/sys v0.0.0-20211019181941-9d821ace8654 // indirect
-	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
 )
@@ -0,0 +1,75 @@
+package defaults
+
+import (
+	"strings"
+	"time"
+
+	"github.com/marco-sacchi/go2jsonc/testdata/multipkg/network"
+)
+
+//go:generate go2jsonc -type Syntax -out syntax.jsonc
+
+// Level defines a verbosity level.
+type Level uint8
+
+// names is a named slice used to test conversions of composite literals.
+type names []string
+
+// Endpoint defines a remote endpoint.
+type Endpoint struct {
+	Host string // Host name.
+	Port int    // Port number.
+}
+
+// Syntax lists the expression forms supported in Defaults functions.
+type Syntax struct {
+	Literal   string          // Basic literal.
+	Constant  int             // Constant expression.
+	Converted uint8           // Conversion of a constant.
+	Level     Level           // Conversion to a named type.
+	Timeout   time.Duration   // Conversion of a qualified constant expression.
+	Started   time.Time       // Call to time.Date with constant arguments.
+	Paren     float64         // Parenthesized expression.
+	Status    network.Status  // Qualified composite literal.
+	StatusPtr *network.Status // Address of a qualified composite literal.
+	Endpoint  *Endpoint       // Address of a composite literal.
+	Endpoints []*Endpoint     // Addresses of composite literals with elided type.
+	Names     []string        // Conversion of a composite literal.
+	Origin    Endpoint        // Unkeyed composite literal.
+	Slots     [3]int          // Array literal with indexed elements.
+	Dynamic   string          // Non-constant expression, rendered as zero value.
+	Missing   *int            // Pointer without default value.
+}
+
+func SyntaxDefaults() *Syntax {
+	cfg := Syntax{
+		Literal:   "literal",
+		Constant:  2 * 1024,
+		Converted: uint8(3),
+		Level:     Level(2),
+		Timeout:   time.Duration(5 * time.Second),
+		Started:   time.Date(2023, time.March, 4, 10, 30, 0, 0, time.UTC),
+		Paren:     (1.5),
+		Status: network.Status{
+			Connected: true,
+			State:     network.StateConnected,
+		},
+		StatusPtr: &network.Status{
+			State: network.StateFailed,
+		},
+		Endpoint: &Endpoint{
+			Host: "localhost",
+			Port: 8080,
+		},
+		Endpoints: []*Endpoint{
+			{Host: "first", Port: 1},
+			&Endpoint{Host: "second", Port: 2},
+		},
+		Names:   []string(names{"a", "b"}),
+		Origin:  Endpoint{"origin", 80},
+		Slots:   [3]int{2: 5},
+		Dynamic: strings.ToUpper("dynamic"),
+	}
+
+	return &cfg
+}
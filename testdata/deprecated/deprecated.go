@@ -0,0 +1,41 @@
+package deprecated
+
+//go:generate go2jsonc -type Deprecated -out deprecated.jsonc
+
+// Limits defines the request limits.
+type Limits struct {
+	Rate  int `json:"rate"`  // Requests per second.
+	Burst int `json:"burst"` // Maximum burst size.
+}
+
+// Deprecated tests the rendering of deprecated fields.
+type Deprecated struct {
+	Hosts []string `json:"hosts"` // Server hosts.
+
+	// Server host.
+	//
+	// Deprecated: use hosts instead.
+	Host string `json:"host" validate:"required"`
+
+	// Request limits.
+	//
+	// Deprecated: limits are configured by the gateway.
+	Limits Limits `json:"limits"`
+
+	// Timeout in seconds. Deprecated: values are not a paragraph, so the field is not deprecated.
+	Timeout int `json:"timeout"`
+
+	// Server port.
+	//
+	// Deprecated: use hosts with ports instead.
+	Port int `json:"port"`
+}
+
+func DeprecatedDefaults() *Deprecated {
+	return &Deprecated{
+		Hosts:  []string{"localhost:8080"},
+		Host:   "localhost",
+		Limits: Limits{Rate: 100, Burst: 10},
+		Port:   8080,
+	}
+}
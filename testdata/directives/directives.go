@@ -0,0 +1,79 @@
+package directives
+
+//go:generate go2jsonc -type Directives -out directives.jsonc
+
+// Credentials defines access credentials.
+//
+//go2jsonc:secret
+type Credentials struct {
+	User     string // User name.
+	Password string // User password.
+}
+
+// Point defines a point on a plane.
+//
+//go2jsonc:collapse
+type Point struct {
+	X int // Abscissa.
+	Y int // Ordinate.
+}
+
+// Legacy defines the settings of the previous version.
+//
+//go2jsonc:deprecated=settings are ignored since version 2.
+type Legacy struct {
+	Mode int // Compatibility mode.
+}
+
+// Directives tests the rendering of go2jsonc directives.
+type Directives struct {
+	// Internal state, not configurable.
+	//go2jsonc:ignore
+	State int
+
+	// Listening port.
+	//go2jsonc:example=9090
+	Port int `json:"port"`
+
+	// Log level.
+	//go2jsonc:example="info"
+	Level string
+
+	// Service tags.
+	//go2jsonc:example=["web", "api"]
+	Tags []string
+
+	// Database password.
+	// go2jsonc:secret
+	// go2jsonc:required
+	// go2jsonc:name=db_password
+	Password string
+
+	Admin  Credentials // Administrator credentials.
+	Origin Point       // Origin of the path.
+
+	// Path points.
+	//go2jsonc:collapse
+	Path []Point
+
+	Legacy Legacy // Legacy settings.
+
+	// Connection timeout in seconds.
+	//go2jsonc:deprecated
+	Timeout int
+}
+
+func DirectivesDefaults() *Directives {
+	return &Directives{
+		State:    1,
+		Port:     8080,
+		Password: "development",
+		Admin: Credentials{
+			User:     "admin",
+			Password: "admin",
+		},
+		Origin:  Point{X: 1, Y: 2},
+		Path:    []Point{{X: 1, Y: 2}, {X: 3, Y: 4}},
+		Timeout: 30,
+	}
+}
@@ -0,0 +1,213 @@
+package enums
+
+import (
+	"strings"
+
+	"github.com/marco-sacchi/go2jsonc/testdata/enums/levels"
+)
+
+//go:generate go2jsonc -type Enums -out enums.jsonc
+
+// Level defines a logging level, marshaled by the name returned by String.
+type Level int
+
+const (
+	LevelDebug Level = iota // Verbose output for troubleshooting.
+	LevelInfo               // Informational messages.
+	LevelError              // Errors only.
+)
+
+// String implements the fmt.Stringer interface.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// Protocol defines a network protocol, marshaled by the name in protocolNames.
+type Protocol uint8
+
+const (
+	ProtocolTCP  Protocol = iota + 1 // Transmission Control Protocol.
+	ProtocolUDP                      // User Datagram Protocol.
+	ProtocolQUIC                     // QUIC transport protocol.
+)
+
+// protocolNames maps protocols to their names.
+var protocolNames = [...]string{
+	ProtocolTCP:  "tcp",
+	ProtocolUDP:  "udp",
+	ProtocolQUIC: "quic",
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p *Protocol) MarshalText() ([]byte, error) {
+	return []byte(protocolNames[*p]), nil
+}
+
+// Color defines a color, marshaled by the name returned by a stringer-like String method.
+type Color int
+
+const (
+	Red   Color = iota // Red color.
+	Green              // Green color.
+	Blue               // Blue color.
+)
+
+const colorName = "RedGreenBlue"
+
+var colorIndex = [...]uint8{0, 3, 8, 12}
+
+// String implements the fmt.Stringer interface.
+func (c Color) String() string {
+	if c < 0 || int(c) >= len(colorIndex)-1 {
+		return "Color(?)"
+	}
+	return colorName[colorIndex[c]:colorIndex[c+1]]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Severity defines an alert severity, marshaled by a text not evaluable statically.
+type Severity int
+
+const (
+	SeverityLow  Severity = iota // Low severity.
+	SeverityHigh                 // High severity.
+)
+
+// String implements the fmt.Stringer interface.
+func (s Severity) String() string {
+	return [...]string{"Low", "High"}[s]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(s.String())), nil
+}
+
+// Mode defines an operating mode, marshaled as number.
+type Mode int
+
+const (
+	ModeActive  Mode = iota // Serving requests.
+	ModeStandby             // Waiting for failover.
+)
+
+// String implements the fmt.Stringer interface, not used by encoding/json.
+func (m Mode) String() string {
+	return [...]string{"active", "standby", "maintenance"}[m]
+}
+
+// Permission defines access permissions, combinable as bit flags.
+type Permission uint
+
+const (
+	PermRead  Permission = 1 << iota // Read access.
+	PermWrite                        // Write access.
+	PermExec                         // Execute access.
+
+	PermAll = PermRead | PermWrite | PermExec // All permissions.
+)
+
+// Stage defines a deployment stage, an enumeration of powers of two and other values.
+type Stage int
+
+const (
+	StageDraft   Stage = iota // Not submitted.
+	StageReview               // Under review.
+	StageApprove              // Approved.
+	StageBuild                // Building.
+	StageDeploy               // Deploying.
+)
+
+// Speed defines a processing speed.
+type Speed string
+
+const (
+	SpeedFast  Speed = "fast"          // Fast processing.
+	SpeedSlow  Speed = "slow"          // Slow processing.
+	SpeedPlaid Speed = "plaid \"max\"" // Beyond ludicrous.
+)
+
+// Ratio defines a sampling ratio.
+type Ratio float64
+
+const (
+	RatioNone  Ratio = 0       // No samples.
+	RatioThird Ratio = 1.0 / 3 // One sample out of three.
+	RatioHalf  Ratio = 0.5     // One sample out of two.
+	RatioAll   Ratio = 1       // All samples.
+)
+
+// Toggle defines a switch.
+type Toggle bool
+
+const (
+	On  Toggle = true  // Switched on.
+	Off Toggle = false // Switched off.
+)
+
+// Enums tests the rendering of typed constants.
+type Enums struct {
+	Level     Level      `json:"level"`     // Logging level.
+	Fallback  Level      `json:"fallback"`  // Fallback logging level.
+	Protocols []Protocol `json:"protocols"` // Enabled protocols.
+	Color     Color      `json:"color"`     // Theme color.
+	Severity  Severity   `json:"severity"`  // Alert severity.
+	Mode      Mode       `json:"mode"`      // Operating mode.
+
+	Permissions Permission   `json:"permissions"` // Access permissions.
+	Admin       Permission   `json:"admin"`       // Administrator permissions.
+	Grants      []Permission `json:"grants"`      // Granted permissions.
+	Stage       Stage        `json:"stage"`       // Deployment stage.
+
+	Speed     Speed   `json:"speed"`     // Processing speed.
+	Speeds    []Speed `json:"speeds"`    // Supported speeds.
+	MinSpeed  Speed   `json:"min_speed"` // Minimum processing speed.
+	Ratio     Ratio   `json:"ratio"`     // Sampling ratio.
+	MaxRatio  Ratio   `json:"max_ratio"` // Maximum sampling ratio.
+	Custom    Ratio   `json:"custom"`    // Custom sampling ratio.
+	Switch    Toggle  `json:"switch"`    // Main switch.
+	Secondary Toggle  `json:"secondary"` // Secondary switch.
+
+	Priority levels.Priority `json:"priority"` // Scheduling priority.
+}
+
+func EnumsDefaults() *Enums {
+	return &Enums{
+		Level:     LevelInfo,
+		Protocols: []Protocol{ProtocolTCP, ProtocolQUIC},
+		Color:     Blue,
+		Severity:  SeverityHigh,
+		Mode:      ModeStandby,
+
+		Permissions: PermRead | PermWrite,
+		Admin:       PermAll,
+		Grants:      []Permission{PermRead, PermRead | PermExec},
+		Stage:       StageDeploy,
+
+		Speed:  SpeedSlow,
+		Speeds: []Speed{SpeedFast, SpeedPlaid},
+		Ratio:  RatioThird,
+		Custom: 0.25,
+		Switch: Off,
+
+		Priority: PriorityCritical,
+	}
+}
@@ -0,0 +1,7 @@
+// Package idle registers the idle priority, declared outside the levels package.
+package idle
+
+import "github.com/marco-sacchi/go2jsonc/testdata/enums/levels"
+
+// PriorityIdle runs tasks only when no others are pending.
+const PriorityIdle levels.Priority = -1 // Idle priority.
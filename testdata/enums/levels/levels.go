@@ -0,0 +1,10 @@
+package levels
+
+// Priority defines a scheduling priority.
+type Priority int
+
+const (
+	PriorityLow    Priority = iota // Low priority.
+	PriorityNormal                 // Normal priority.
+	PriorityHigh                   // High priority.
+)
@@ -0,0 +1,14 @@
+package enums
+
+import (
+	"github.com/marco-sacchi/go2jsonc/testdata/enums/levels"
+
+	// Declares constants of levels.Priority, but no types used by fields.
+	_ "github.com/marco-sacchi/go2jsonc/testdata/enums/idle"
+)
+
+// ModeMaintenance is declared in a file other than the one of its type.
+const ModeMaintenance Mode = 2 // Under maintenance.
+
+// PriorityCritical extends the priorities declared in the levels package.
+const PriorityCritical levels.Priority = 9 // Critical priority.
@@ -0,0 +1,40 @@
+package examples
+
+//go:generate go2jsonc -type Examples -out examples.jsonc
+
+// Backend defines a backend server.
+type Backend struct {
+	Address string `json:"address"` // Backend address.
+	Weight  int    `json:"weight"`  // Load balancing weight.
+}
+
+// TLS defines the TLS configuration.
+type TLS struct {
+	Cert string `json:"cert"` // Certificate file path.
+	Key  string `json:"key"`  // Key file path.
+}
+
+// Examples tests the rendering of examples of fields without default value.
+type Examples struct {
+	Name     string             `json:"name"`     // Service name.
+	Backends []Backend          `json:"backends"` // Backend servers.
+	Replicas map[string]Backend `json:"replicas"` // Replica servers by region.
+	Weights  map[int]float64    `json:"weights"`  // Weights by priority.
+	TLS      *TLS               `json:"tls"`      // TLS configuration, disabled when null.
+	Hosts    []string           `json:"hosts"`    // Additional hosts.
+
+	// Service tags.
+	//go2jsonc:example=["web", "api"]
+	Tags []string `json:"tags"`
+
+	Level string `json:"level" jsonc:"example=\"info\""` // Log level.
+
+	Aliases []string `json:"aliases"` // Service aliases.
+}
+
+func ExamplesDefaults() *Examples {
+	return &Examples{
+		Name:    "api",
+		Aliases: []string{},
+	}
+}
@@ -0,0 +1,50 @@
+package format
+
+//go:generate go2jsonc -type Format -out format.jsonc
+
+// Level defines a logging level.
+type Level int
+
+const (
+	LevelDebug Level = iota // Verbose output for troubleshooting.
+	LevelInfo               // Informational messages.
+	LevelError              // Errors only.
+)
+
+// Server defines the network settings of the server.
+type Server struct {
+	// Address the server listens on, as host:port. An empty host listens on all the interfaces
+	// of the machine, both IPv4 and IPv6 ones.
+	Address    string `json:"address"`
+	Timeout    int    `json:"timeout"`     // Timeout in seconds.
+	MaxRetries int    `json:"max_retries"` // Connection retries.
+	KeepAlive  bool   `json:"keep_alive"`  // Keep connections alive.
+	Backlog    int    `json:"backlog"`     // Pending connections queue length, zero for the default,
+}
+
+// Format tests the formatting options of the generated code.
+type Format struct {
+	Name  string `json:"name"` // Service name.
+	Debug bool   `json:"debug"`
+
+	// Logging level of the service, messages with a lower level are discarded. Comments containing */ are
+	// escaped in block comments.
+	Level Level `json:"level"`
+
+	Server Server   `json:"server"`
+	Hosts  []string `json:"hosts"` // Additional hosts.
+	Port   int      `json:"port"`
+}
+
+func FormatDefaults() *Format {
+	return &Format{
+		Name:  "api",
+		Level: LevelInfo,
+		Server: Server{
+			Address: ":8080",
+			Timeout: 30,
+		},
+		Hosts: []string{"localhost", "example.com"},
+		Port:  8080,
+	}
+}
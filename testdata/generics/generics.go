@@ -0,0 +1,55 @@
+package generics
+
+import "github.com/marco-sacchi/go2jsonc/testdata/multipkg/network"
+
+//go:generate go2jsonc -type Generics -out generics.jsonc
+
+// Conn defines a connection.
+type Conn struct {
+	Addr    string // Remote address.
+	Timeout int    // Timeout in seconds.
+}
+
+// Pool defines a pool of items.
+type Pool[T any] struct {
+	Items   []T // Pooled items.
+	Size    int // Pool size.
+	Default T   // Default item.
+}
+
+// Option defines an optional value.
+type Option[V any] struct {
+	Set   bool // Value is set.
+	Value V    // Optional value.
+}
+
+// Pair defines a map of values by key.
+type Pair[K comparable, V any] struct {
+	Values map[K]V // Values by key.
+}
+
+// Generics tests the rendering of instantiated generic structs.
+type Generics struct {
+	Conns   Pool[Conn]             // Connections pool.
+	Retries Option[int]            // Retries count.
+	Labels  Pair[string, []int]    // Labels values.
+	Pools   []Pool[int]            // Integer pools.
+	Status  Option[network.Status] // Network status.
+}
+
+func GenericsDefaults() *Generics {
+	return &Generics{
+		Conns: Pool[Conn]{
+			Items: []Conn{
+				{Addr: "localhost:5432", Timeout: 10},
+			},
+			Size: 4,
+		},
+		Retries: Option[int]{Set: true, Value: 3},
+		Labels: Pair[string, []int]{
+			Values: map[string][]int{
+				"primes": {2, 3, 5},
+			},
+		},
+	}
+}
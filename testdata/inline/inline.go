@@ -0,0 +1,51 @@
+package inline
+
+//go:generate go2jsonc -type Inline -out inline.jsonc
+
+// Inline tests the rendering of anonymous structs declared inline.
+type Inline struct {
+	// Limits documentation block.
+	Limits struct {
+		CPU int `json:"cpu"` // CPU cores.
+		Mem int `json:"mem"` // Memory in megabytes.
+	} `json:"limits"` // Resource limits.
+
+	// Access rules.
+	Rules []struct {
+		Path  string // Path prefix.
+		Allow bool   // Allow access.
+	}
+
+	Weights map[string]struct {
+		Value int // Weight value.
+	} // Weights by name.
+
+	Optional *struct {
+		Enabled bool // Enabled flag.
+	} // Optional section.
+
+	Nested struct {
+		Inner struct {
+			Value string // Inner value.
+		} // Inner section.
+	} // Nested inline structs.
+}
+
+func InlineDefaults() *Inline {
+	return &Inline{
+		Limits: struct {
+			CPU int `json:"cpu"`
+			Mem int `json:"mem"`
+		}{
+			CPU: 2,
+			Mem: 512,
+		},
+		Rules: []struct {
+			Path  string
+			Allow bool
+		}{
+			{Path: "/public", Allow: true},
+			{Path: "/admin", Allow: false},
+		},
+	}
+}
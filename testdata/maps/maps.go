@@ -0,0 +1,110 @@
+package maps
+
+import "net/netip"
+
+//go:generate go2jsonc -type Maps -out maps.jsonc
+
+const (
+	keyAlpha = "alpha"
+	keyBeta  = "beta"
+)
+
+// Level defines a logging level, marshaled as text.
+type Level int
+
+const (
+	LevelDebug Level = iota // Verbose output.
+	LevelInfo               // Informational messages.
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (l Level) MarshalText() ([]byte, error) {
+	switch l {
+	case LevelDebug:
+		return []byte("debug"), nil
+	case LevelInfo:
+		return []byte("info"), nil
+	}
+
+	return nil, nil
+}
+
+// Endpoint defines a remote endpoint.
+type Endpoint struct {
+	Host string // Host name.
+	Port int    // Port number.
+}
+
+// Maps tests maps with non-string keys, nested maps and slices.
+type Maps struct {
+	Ports     map[int]string              // Services by port number.
+	Weights   map[uint8]float64           // Weights by priority.
+	Constants map[string]int              // Keys defined by constants.
+	Escaped   map[string]string           // Keys requiring escaping.
+	Hosts     map[netip.Addr]string       // Host names by address.
+	Nested    map[string]map[string]int   // Nested maps.
+	Lists     map[string][]string         // Maps of slices.
+	Items     []map[string]int            // Slices of maps.
+	Matrix    [][]int                     // Slices of slices.
+	Endpoints map[string]Endpoint         // Maps of structs.
+	Groups    map[string][]Endpoint       // Maps of slices of structs.
+	Empty     map[string]map[string]int   // Nested maps without default.
+	Deep      map[string]map[int][]string // Deeply nested maps.
+	Levels    map[Level]int               // Keys marshaled as text.
+}
+
+func MapsDefaults() *Maps {
+	return &Maps{
+		Ports: map[int]string{
+			80:  "http",
+			443: "https",
+		},
+		Weights: map[uint8]float64{
+			1: 0.5,
+			2: 0.25,
+		},
+		Constants: map[string]int{
+			keyAlpha: 1,
+			keyBeta:  2,
+		},
+		Escaped: map[string]string{
+			`back\slash`: "1",
+			"tab\there":  "2",
+		},
+		Hosts: map[netip.Addr]string{
+			netip.MustParseAddr("10.0.0.1"): "gateway",
+		},
+		Nested: map[string]map[string]int{
+			"outer": {
+				"inner": 1,
+			},
+		},
+		Lists: map[string][]string{
+			"fruits": {"apple", "pear"},
+		},
+		Items: []map[string]int{
+			{"a": 1},
+			{"b": 2, "c": 3},
+		},
+		Matrix: [][]int{
+			{1, 2},
+			{3, 4},
+		},
+		Endpoints: map[string]Endpoint{
+			"local": {Host: "localhost", Port: 8080},
+		},
+		Groups: map[string][]Endpoint{
+			"backends": {
+				{Host: "backend1", Port: 9000},
+			},
+		},
+		Deep: map[string]map[int][]string{
+			"level": {
+				1: {"one"},
+			},
+		},
+		Levels: map[Level]int{
+			LevelInfo: 1,
+		},
+	}
+}
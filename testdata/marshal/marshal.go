@@ -0,0 +1,63 @@
+package marshal
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+)
+
+//go:generate go2jsonc -type Marshal -out marshal.jsonc
+
+// URL wraps url.URL marshaling it as text.
+type URL struct {
+	url.URL
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u URL) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// LogLevel defines a logging level marshaled by name.
+type LogLevel int
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (l *LogLevel) MarshalText() ([]byte, error) {
+	return []byte("info"), nil
+}
+
+// Raw defines a value marshaled as JSON.
+type Raw struct {
+	Data []byte
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Raw) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r.Data))
+}
+
+// Marshal tests the rendering of types implementing custom marshalers.
+type Marshal struct {
+	Address  net.IP   // Listening address.
+	Peers    []net.IP // Peers addresses.
+	Endpoint URL      // Remote endpoint.
+	Backup   *URL     // Backup endpoint.
+	Level    LogLevel // Logging level.
+	Payload  Raw      // Raw payload.
+}
+
+func mustParseURL(s string) URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return URL{URL: *u}
+}
+
+func MarshalDefaults() *Marshal {
+	return &Marshal{
+		Address:  net.ParseIP("127.0.0.1"),
+		Endpoint: mustParseURL("https://example.com/api"),
+	}
+}
@@ -0,0 +1,51 @@
+package named
+
+import "github.com/marco-sacchi/go2jsonc/testdata/multipkg/network"
+
+//go:generate go2jsonc -type Named -out named.jsonc
+
+// Hosts defines a list of host names.
+type Hosts []string
+
+// Labels defines labels by name.
+type Labels map[string]string
+
+// Statuses defines a list of connection statuses.
+type Statuses []network.Status
+
+// Status is an alias of the connection status.
+type Status = network.Status
+
+// State is an alias of the connection state.
+type State = network.ConnState
+
+// Ports is an alias of a list of ports.
+type Ports = []int
+
+// Named tests the rendering of named slices and maps and of type aliases.
+type Named struct {
+	Hosts    Hosts    // Server host names.
+	Labels   Labels   // Server labels.
+	Backends Statuses // Backends status.
+	Uplink   Status   // Uplink status.
+	State    State    // Uplink state.
+	Ports    Ports    // Listening ports.
+	Groups   []Hosts  // Host groups.
+}
+
+// Alias is an alias of the Named struct.
+type Alias = Named
+
+func NamedDefaults() *Named {
+	return &Named{
+		Hosts:  Hosts{"alpha", "beta"},
+		Labels: Labels{"env": "prod"},
+		Uplink: Status{
+			Connected: true,
+			State:     network.StateConnected,
+		},
+		State:  network.StateConnecting,
+		Ports:  Ports{80, 443},
+		Groups: []Hosts{{"gamma"}, {}},
+	}
+}
@@ -0,0 +1,42 @@
+package naming
+
+//go:generate go2jsonc -type Naming -naming snake -out naming_snake.jsonc
+
+// Limits defines the limits of a connection.
+type Limits struct {
+	MaxConns    int // Maximum number of connections.
+	IdleTimeout int // Idle timeout in seconds.
+}
+
+// Common defines the settings shared by the services.
+type Common struct {
+	LogLevel string // Logging level.
+}
+
+// Naming tests the naming strategies of the keys of untagged fields.
+type Naming struct {
+	Common
+
+	ServiceName string   // Service name.
+	APIKey      string   // Key of the remote API.
+	UserID      int      // Identifier of the service user.
+	AllowedIPs  []string // Addresses allowed to connect.
+	HTTPServer  Limits   // Limits of the HTTP server.
+	IPv4Only    bool     // Listen on IPv4 interfaces only.
+	Retry_Count int      // Connection retries.
+
+	TimeoutMs int  `json:"timeout"`    // Timeout in milliseconds, tagged.
+	Verbose   bool `json:",omitempty"` // Verbose output, tagged without name.
+
+	// Backup server, renamed by directive.
+	//go2jsonc:name=backup
+	BackupServer Limits
+}
+
+func NamingDefaults() *Naming {
+	return &Naming{
+		ServiceName: "api",
+		APIKey:      "secret",
+		HTTPServer:  Limits{MaxConns: 100, IdleTimeout: 30},
+	}
+}
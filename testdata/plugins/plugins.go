@@ -0,0 +1,54 @@
+package plugins
+
+//go:generate go2jsonc -type Plugins -out plugins.jsonc
+
+// Sink defines an output sink.
+type Sink interface {
+	Write(p []byte) (int, error)
+}
+
+// FileSink writes to a file.
+type FileSink struct {
+	Path   string // File path.
+	Append bool   // Append to existing file.
+}
+
+// Write implements the Sink interface.
+func (f *FileSink) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// HTTPSink posts to a remote endpoint.
+type HTTPSink struct {
+	URL     string // Endpoint URL.
+	Retries int    // Retries count.
+}
+
+// Write implements the Sink interface.
+func (h HTTPSink) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Plugins tests the rendering of interface fields.
+type Plugins struct {
+	Output Sink // Main output, all implementations are listed.
+
+	// Audit output.
+	// go2jsonc:oneof=FileSink
+	Audit Sink
+
+	//go2jsonc:oneof=HTTPSink
+	Remote Sink // Remote output.
+
+	Sinks []Sink      // Additional sinks.
+	Extra interface{} // Free-form extra data.
+	Meta  any         // Free-form metadata.
+}
+
+func PluginsDefaults() *Plugins {
+	return &Plugins{
+		Output: &FileSink{Path: "/var/log/app.log"},
+		Extra:  "x",
+		Meta:   3,
+	}
+}
@@ -0,0 +1,39 @@
+package required
+
+//go:generate go2jsonc -type Required -out required.jsonc
+
+// Database defines the database connection.
+type Database struct {
+	DSN string `json:"dsn" validate:"required"` // Data source name.
+
+	// Connection pool size.
+	PoolSize int `json:"pool_size,omitempty"`
+}
+
+// Backend defines a backend server.
+type Backend struct {
+	// Backend address.
+	//go2jsonc:required
+	Address string `json:"address"`
+
+	Weight *int `json:"weight"` // Load balancing weight.
+}
+
+// Required tests the rendering of required and optional keys.
+type Required struct {
+	Name     string             `json:"name" validate:"required"` // Service name.
+	Database Database           `json:"database"`                 // Database connection.
+	Backends []Backend          `json:"backends"`                 // Backend servers.
+	Replicas map[string]Backend `json:"replicas"`                 // Replica servers by region.
+	Debug    *bool              `json:"debug"`                    // Debug mode.
+	Internal string             `json:"-"`                        // Internal state.
+}
+
+func RequiredDefaults() *Required {
+	return &Required{
+		Backends: []Backend{
+			{Address: "10.0.0.1:80"},
+			{Address: "10.0.0.2:80"},
+		},
+	}
+}
@@ -0,0 +1,6 @@
+package invalid
+
+// Invalid tests the validation of jsonc tags.
+type Invalid struct {
+	Port int `jsonc:"range=1..65535,default=8080"` // Listening port.
+}
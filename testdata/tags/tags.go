@@ -0,0 +1,25 @@
+package tags
+
+//go:generate go2jsonc -type Tags -out tags.jsonc
+
+// Tags tests the rendering of jsonc tags.
+type Tags struct {
+	Name  string `json:"name"`                                             // Service name.
+	Debug bool   `json:"debug" jsonc:"hidden"`                             // Debug mode, for developers only.
+	Host  string `json:"host" jsonc:"section=Network,example=\"0.0.0.0\""` // Listening address.
+	Port  int    `json:"port" jsonc:"example=8080,range=1..65535"`         // Listening port.
+
+	// Allowed origins.
+	Origins []string `json:"origins" jsonc:"example=[\"https://example.com\", \"https://example.org\"]"`
+
+	Workers int     `json:"workers" jsonc:"section=Limits,range=1.."` // Worker count.
+	Ratio   float64 `json:"ratio" jsonc:"range=..0.5"`                // Sampling ratio.
+}
+
+func TagsDefaults() *Tags {
+	return &Tags{
+		Name:    "api",
+		Port:    9000,
+		Workers: 4,
+	}
+}
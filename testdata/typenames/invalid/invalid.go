@@ -0,0 +1,8 @@
+package invalid
+
+// Invalid tests the error reported for an invalid type directive.
+type Invalid struct {
+	// Service name.
+	//go2jsonc:type=bold
+	Name string `json:"name"`
+}
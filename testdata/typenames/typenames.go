@@ -0,0 +1,56 @@
+package typenames
+
+import (
+	"net"
+	"time"
+
+	"github.com/marco-sacchi/go2jsonc/testdata/multipkg/network"
+)
+
+//go:generate go2jsonc -type TypeNames -out typenames.jsonc
+
+// Option defines an optional value.
+type Option[V any] struct {
+	Set   bool // Value is set.
+	Value V    // Optional value.
+}
+
+// Limits defines the limits of a connection.
+type Limits struct {
+	Rate  float64 `json:"rate"`  // Maximum rate in requests per second.
+	Burst uint16  `json:"burst"` // Maximum burst size.
+}
+
+// TypeNames tests the styles of the field types in documentation.
+type TypeNames struct {
+	Option[network.Status]
+
+	Name     string                      `json:"name"`     // Service name.
+	Enabled  bool                        `json:"enabled"`  // Service enabled.
+	States   []network.ConnState         `json:"states"`   // Accepted connection states.
+	Weights  map[string]float32          `json:"weights"`  // Weights by backend.
+	Key      []byte                      `json:"key"`      // Encryption key.
+	Address  net.IP                      `json:"address"`  // Listening address.
+	Timeout  time.Duration               `json:"timeout"`  // Connection timeout.
+	Started  time.Time                   `json:"started"`  // Start time.
+	Limits   *Limits                     `json:"limits"`   // Connection limits.
+	Defaults map[string]Option[[]Limits] `json:"defaults"` // Default limits by backend.
+	Extra    any                         `json:"extra"`    // Extra data.
+	Workers  uint                        `json:"workers"`  // Number of workers.
+	Priority int8                        `json:"priority"` // Scheduling priority.
+
+	// Retries count, type is always hidden.
+	//go2jsonc:type=hide
+	Retries int `json:"retries"`
+
+	// Fallback limits, type is always shown.
+	//go2jsonc:type=show
+	Fallback Limits `json:"fallback"`
+}
+
+func TypeNamesDefaults() *TypeNames {
+	return &TypeNames{
+		Option: Option[network.Status]{Set: true},
+		Name:   "api",
+	}
+}
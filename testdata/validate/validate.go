@@ -0,0 +1,25 @@
+package validate
+
+//go:generate go2jsonc -type Validate -out validate.jsonc
+
+// Validate tests the rendering of validation tags constraints.
+type Validate struct {
+	Port     int               `validate:"required,min=1,max=65535"` // Listening port.
+	Workers  int               `validate:"gte=1"`                    // Worker count.
+	Ratio    float64           `validate:"gt=0,lt=1"`                // Sampling ratio.
+	Name     string            `validate:"required,min=3,max=32"`    // Service name.
+	Code     string            `validate:"len=6"`                    // Activation code.
+	Mode     string            `validate:"oneof=fast safe"`          // Processing mode.
+	Admin    string            `validate:"omitempty,email"`          // Administrator email.
+	Hosts    []string          `validate:"min=1,dive,hostname"`      // Backend hosts.
+	Labels   map[string]string `validate:"max=10"`                   // Service labels.
+	Fallback string            `validate:"ipv4|ipv6"`                // Fallback address.
+	Comment  string            `validate:"-"`                        // Free comment.
+}
+
+func ValidateDefaults() *Validate {
+	return &Validate{
+		Port: 8080,
+		Mode: "fast",
+	}
+}
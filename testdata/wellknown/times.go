@@ -0,0 +1,27 @@
+package wellknown
+
+import "time"
+
+//go:generate go2jsonc -type Times -out times.jsonc
+
+// Times tests the rendering of time types.
+type Times struct {
+	Timeout  time.Duration   // Connection timeout.
+	Interval time.Duration   // Polling interval.
+	Backoff  []time.Duration // Retry backoff steps.
+
+	Started time.Time  // Service start time.
+	Created time.Time  // Creation time.
+	Expires *time.Time // Expiration time, never when null.
+}
+
+func TimesDefaults() *Times {
+	return &Times{
+		Timeout: 30 * time.Second,
+		Backoff: []time.Duration{
+			100 * time.Millisecond,
+			time.Second,
+		},
+		Started: time.Date(2023, time.January, 2, 15, 4, 5, 0, time.UTC),
+	}
+}